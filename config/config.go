@@ -1,15 +1,116 @@
 package config
 
+import (
+	"github.com/pkg/errors"
+	"os"
+	"strings"
+	"time"
+)
+
 type AppConfig struct {
 	GRPCAddr string
 	HTTPAddr string
-	RepoPath string
+
+	// Directory the served repos live under. Set through `GITDB_REPO_ROOT`,
+	// which is required.
+	RepoRoot string
+
+	// Identity used for commits gitdb creates on its own behalf.
+	CommitterName  string
+	CommitterEmail string
+
+	// Repos that coalesce concurrent writes into group commits, mapped to how
+	// long writes queue up before they are committed together. Set through
+	// `GITDB_BATCH_WINDOWS`, e.g. `team/config=50ms,team/flags=1s`.
+	BatchWindows map[string]time.Duration
+
+	// Repos rejecting writes that leave malformed `.json` files. Set through
+	// `GITDB_VALIDATE_JSON`, e.g. `team/config,team/flags`.
+	ValidateJSONRepos map[string]bool
+
+	// Token that grants admin privileges, e.g. setting explicit commit times.
+	// Set through `GITDB_ADMIN_TOKEN`, admin privileges are disabled when empty.
+	AdminToken string
+
+	// How long idempotency keys of writes are honored. Set through
+	// `GITDB_IDEMPOTENCY_WINDOW`, e.g. `1h`, and 24 hours when unset.
+	IdempotencyWindow time.Duration
+
+	// Armored OpenPGP private key every commit gitdb creates is signed with.
+	// Set through `GITDB_SIGNING_KEY`, commits are left unsigned when empty.
+	SigningKeyPath string
+
+	// Armored OpenPGP public keys of other signers GetObject trusts when
+	// verifying signatures, besides the signing key. Set through
+	// `GITDB_TRUSTED_KEYS`.
+	TrustedKeysPath string
 }
 
 func NewAppConfig() (*AppConfig, error) {
+	repoRoot := os.Getenv("GITDB_REPO_ROOT")
+	if repoRoot == "" {
+		return nil, errors.New("GITDB_REPO_ROOT is not set, it has to name the directory repos live under")
+	}
+	batchWindows, err := parseBatchWindows(os.Getenv("GITDB_BATCH_WINDOWS"))
+	if err != nil {
+		return nil, err
+	}
+	idempotencyWindow := 24 * time.Hour
+	if s := os.Getenv("GITDB_IDEMPOTENCY_WINDOW"); s != "" {
+		if idempotencyWindow, err = time.ParseDuration(s); err != nil {
+			return nil, errors.Wrapf(err, "GITDB_IDEMPOTENCY_WINDOW")
+		}
+		if idempotencyWindow < 0 {
+			return nil, errors.Errorf("GITDB_IDEMPOTENCY_WINDOW `%s` is negative", s)
+		}
+	}
 	return &AppConfig{
 		GRPCAddr: "localhost:8080",
 		HTTPAddr: "localhost:8081",
-		RepoPath: "/Users/lingy/Uber/tmp/repo2/repo.git",
+		RepoRoot: repoRoot,
+
+		CommitterName:  "gitdb",
+		CommitterEmail: "gitdb@localhost",
+
+		BatchWindows:      batchWindows,
+		ValidateJSONRepos: parseRepos(os.Getenv("GITDB_VALIDATE_JSON")),
+
+		AdminToken: os.Getenv("GITDB_ADMIN_TOKEN"),
+
+		IdempotencyWindow: idempotencyWindow,
+
+		SigningKeyPath:  os.Getenv("GITDB_SIGNING_KEY"),
+		TrustedKeysPath: os.Getenv("GITDB_TRUSTED_KEYS"),
 	}, nil
 }
+
+// parseBatchWindows parses comma separated `<repo>=<duration>` pairs.
+func parseBatchWindows(s string) (map[string]time.Duration, error) {
+	windows := map[string]time.Duration{}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, errors.Errorf("batch window `%s` is not `<repo>=<duration>`", pair)
+		}
+		window, err := time.ParseDuration(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "batch window of repo `%s`", kv[0])
+		}
+		windows[strings.TrimSpace(kv[0])] = window
+	}
+	return windows, nil
+}
+
+// parseRepos parses a comma separated list of repos.
+func parseRepos(s string) map[string]bool {
+	repos := map[string]bool{}
+	for _, repo := range strings.Split(s, ",") {
+		if repo = strings.TrimSpace(repo); repo != "" {
+			repos[repo] = true
+		}
+	}
+	return repos
+}
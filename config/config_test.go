@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdempotencyWindow(t *testing.T) {
+	t.Setenv("GITDB_REPO_ROOT", "/repos")
+	for s, want := range map[string]time.Duration{
+		"":   24 * time.Hour,
+		"1h": time.Hour,
+		"0":  0,
+	} {
+		t.Setenv("GITDB_IDEMPOTENCY_WINDOW", s)
+		cfg, err := NewAppConfig()
+		if err != nil {
+			t.Fatalf("window `%s`: %v", s, err)
+		}
+		if cfg.IdempotencyWindow != want {
+			t.Errorf("window `%s`: got %v, want %v", s, cfg.IdempotencyWindow, want)
+		}
+	}
+	for _, s := range []string{"-1h", "day"} {
+		t.Setenv("GITDB_IDEMPOTENCY_WINDOW", s)
+		if _, err := NewAppConfig(); err == nil {
+			t.Errorf("accepted window `%s`", s)
+		}
+	}
+}
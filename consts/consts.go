@@ -4,6 +4,22 @@ import "github.com/pkg/errors"
 
 var ErrNYI = errors.Errorf("NYI")
 
+// ErrConflict is the cause of errors where a write lost a race with another
+// write, e.g. the ref moved away from the expected parent.
+var ErrConflict = errors.Errorf("conflict")
+
+// ErrPermissionDenied is the cause of errors where the caller lacks the
+// privileges for an operation.
+var ErrPermissionDenied = errors.Errorf("permission denied")
+
+// ErrNotFound is the cause of errors where the object a request identifies
+// does not exist, e.g. no commit of the ref precedes the requested time.
+var ErrNotFound = errors.Errorf("not found")
+
 var MaxGRPCMessageSize = 1024 * 1024 * 16 // 16MB
 
 const RefNameMaster = "refs/heads/master"
+
+// AdminTokenKey is the gRPC metadata key carrying the admin token. Over HTTP it
+// is sent as the `Grpc-Metadata-Gitdb-Admin-Token` header.
+const AdminTokenKey = "gitdb-admin-token"
@@ -0,0 +1,139 @@
+package extended_plumbing
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// IsPattern tells whether a delete path is a glob pattern rather than a file or
+// directory path.
+func IsPattern(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// MatchPattern tells whether the file path `p` matches `pattern`. Components
+// match as in path.Match and `**` matches any number of components. A pattern
+// without a slash matches the base name at any depth, like in .gitignore.
+func MatchPattern(pattern, p string) bool {
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(p))
+		return matched
+	}
+	return matchParts(strings.Split(pattern, "/"), strings.Split(p, "/"))
+}
+
+func matchParts(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(parts); i++ {
+				if matchParts(pattern[1:], parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], parts[0]); !matched {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
+
+// CleanDelete is CleanPath for delete paths, which may also be patterns.
+func CleanDelete(p string) (string, error) {
+	cleaned, err := CleanPath(p)
+	if err != nil {
+		return "", err
+	}
+	if IsPattern(cleaned) {
+		for _, part := range strings.Split(cleaned, "/") {
+			if _, err := path.Match(part, ""); err != nil {
+				return "", errors.Errorf("invalid pattern `%s`", p)
+			}
+		}
+	}
+	return cleaned, nil
+}
+
+// ExpandDeletes lists the files of `tree` (nil for an empty tree) removed by
+// `deletes`, sorted. A delete path names a file, a directory whose files are
+// all removed, or a pattern matched against every file. Paths that match
+// nothing are ignored.
+func ExpandDeletes(tree *object.Tree, deletes []string) ([]string, error) {
+	seen := map[string]struct{}{}
+	var patterns []string
+	for _, d := range deletes {
+		p, err := CleanDelete(d)
+		if err != nil {
+			return nil, err
+		}
+		if IsPattern(p) {
+			patterns = append(patterns, p)
+			continue
+		}
+		entry, err := FindEntry(tree, p)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case entry == nil:
+		case entry.Mode == filemode.Dir:
+			subtree, err := tree.Tree(p)
+			if err != nil {
+				return nil, errors.Wrapf(err, "path `%s`", p)
+			}
+			if err := eachFile(subtree, func(f string) {
+				seen[path.Join(p, f)] = struct{}{}
+			}); err != nil {
+				return nil, err
+			}
+		default:
+			seen[p] = struct{}{}
+		}
+	}
+	if len(patterns) > 0 && tree != nil {
+		if err := eachFile(tree, func(f string) {
+			for _, pattern := range patterns {
+				if MatchPattern(pattern, f) {
+					seen[f] = struct{}{}
+					return
+				}
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+	var files []string
+	for f := range seen {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// eachFile calls `fn` with the path of every file under `tree`.
+func eachFile(tree *object.Tree, fn func(string)) error {
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if entry.Mode != filemode.Dir {
+			fn(name)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package extended_plumbing
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/utils/merkletrie"
+	"sort"
+	"strings"
+)
+
+// ChangedPaths lists the files that differ between `from` and `to`, either of
+// which may be nil for an empty tree.
+func ChangedPaths(from, to *object.Tree) ([]string, error) {
+	changes, err := object.DiffTree(from, to)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	seen := map[string]struct{}{}
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name != "" {
+				seen[name] = struct{}{}
+			}
+		}
+	}
+	var paths []string
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// OverlappingPaths returns the entries of `changed` that overlap any of
+// `paths`: the same path, or one being a directory containing the other.
+func OverlappingPaths(paths []string, changed []string) ([]string, error) {
+	var cleaned []string
+	for _, p := range paths {
+		c, err := CleanPath(p)
+		if err != nil {
+			return nil, err
+		}
+		cleaned = append(cleaned, c)
+	}
+	var overlapping []string
+	for _, c := range changed {
+		for _, p := range cleaned {
+			if overlaps(c, p) {
+				overlapping = append(overlapping, c)
+				break
+			}
+		}
+	}
+	return overlapping, nil
+}
+
+// overlaps tells whether the cleaned paths `a` and `b` are the same or one is a
+// directory containing the other.
+func overlaps(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+"/") || strings.HasPrefix(b, a+"/")
+}
+
+type ChangeType int
+
+const (
+	Added ChangeType = iota
+	Modified
+	Deleted
+)
+
+// FileChange is a file that differs between two trees. `From` is zero for an
+// added file and `To` for a deleted one.
+type FileChange struct {
+	Path string
+	Type ChangeType
+	From plumbing.Hash
+	To   plumbing.Hash
+	// Diff is the unified diff of the file if requested.
+	Diff string
+}
+
+// DiffTrees lists the files that differ between `from` and `to`, either of
+// which may be nil for an empty tree, with their unified diff if `text` is set.
+func DiffTrees(from, to *object.Tree, text bool) ([]*FileChange, error) {
+	changes, err := object.DiffTree(from, to)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var fileChanges []*FileChange
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		fc := &FileChange{Path: change.To.Name}
+		switch action {
+		case merkletrie.Insert:
+			fc.Type = Added
+			fc.To = change.To.TreeEntry.Hash
+		case merkletrie.Delete:
+			fc.Type = Deleted
+			fc.Path = change.From.Name
+			fc.From = change.From.TreeEntry.Hash
+		default:
+			fc.Type = Modified
+			fc.From = change.From.TreeEntry.Hash
+			fc.To = change.To.TreeEntry.Hash
+		}
+		// Gitlinks have no blob to diff.
+		if text && change.From.TreeEntry.Mode != filemode.Submodule && change.To.TreeEntry.Mode != filemode.Submodule {
+			patch, err := change.Patch()
+			if err != nil {
+				return nil, errors.Wrapf(err, "path `%s`", fc.Path)
+			}
+			fc.Diff = patch.String()
+		}
+		fileChanges = append(fileChanges, fc)
+	}
+	sort.Slice(fileChanges, func(i, j int) bool {
+		return fileChanges[i].Path < fileChanges[j].Path
+	})
+	return fileChanges, nil
+}
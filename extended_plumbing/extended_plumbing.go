@@ -3,16 +3,37 @@ package extended_plumbing
 import (
 	"github.com/fiibbb/gitdb/consts"
 	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"io/ioutil"
 	"path"
+	"sort"
+	"strings"
 	"time"
 )
 
-// ResolveCommit finds the commit in the ancestry chain identified by `reference`
-// immediately before or equal to the specified `time`.
-func ResolveCommit(repo *git.Repository, reference string, time time.Time) (*object.Commit, error) {
+// Traversal selects the ancestors of a ref ResolveCommit considers.
+type Traversal int
+
+const (
+	// FirstParent follows first parents only, i.e. the history of the ref
+	// itself without the commits merged into it.
+	FirstParent Traversal = iota
+	// FullHistory considers every commit reachable from the ref.
+	FullHistory
+)
+
+// ResolveCommit finds the commit in the ancestry of `reference` committed
+// at or before `time`. With FirstParent it is the first such commit along the
+// first-parent chain, with FullHistory the latest such commit reachable from
+// the ref. The cause of the returned error is consts.ErrNotFound if no commit
+// qualifies, e.g. because `time` predates the repo. FirstParent lookups use
+// `index` if it is not nil and up to date, and walk the history otherwise.
+func ResolveCommit(repo *git.Repository, reference string, time time.Time, traversal Traversal, index *TimeIndex) (*object.Commit, error) {
 	refName := plumbing.ReferenceName(reference)
 	if refName == "" { // default to master if no ref is specified
 		refName = consts.RefNameMaster
@@ -25,23 +46,68 @@ func ResolveCommit(repo *git.Repository, reference string, time time.Time) (*obj
 	if err != nil {
 		return nil, errors.Wrapf(err, "hash `%s`", ref.Hash())
 	}
-	for {
-		if commit.Committer.When.Before(time) {
-			break
+	switch traversal {
+	case FirstParent:
+		commit, err = resolveFirstParent(repo, refName, commit, time, index)
+	case FullHistory:
+		commit, err = resolveFullHistory(commit, time)
+	default:
+		return nil, errors.Errorf("unrecognized traversal `%v`", traversal)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if commit == nil {
+		return nil, errors.Wrapf(consts.ErrNotFound, "no commit of ref `%s` at or before %s", refName, time.String())
+	}
+	return commit, nil
+}
+
+func resolveFirstParent(repo *git.Repository, refName plumbing.ReferenceName, commit *object.Commit, time time.Time, index *TimeIndex) (*object.Commit, error) {
+	if index != nil && commit.Committer.When.After(time) {
+		hash, ok, err := index.Lookup(string(refName), commit.Hash, time)
+		if err != nil {
+			return nil, err
 		}
-		if commit.NumParents() != 1 {
-			return nil, errors.Errorf("no commit found for ref %s time %d, commit %s has %d parent(s)", reference, time.String(), commit.Hash.String(), commit.NumParents())
+		if ok {
+			if hash.IsZero() {
+				return nil, nil
+			}
+			c, err := repo.CommitObject(hash)
+			return c, errors.Wrapf(err, "hash `%s`", hash)
 		}
-		commit, err = commit.Parent(0)
+	}
+	for commit.Committer.When.After(time) {
+		if commit.NumParents() == 0 {
+			return nil, nil
+		}
+		parent, err := commit.Parent(0)
 		if err != nil {
-			return nil, errors.WithStack(err)
+			return nil, errors.Wrapf(err, "parent of `%s`", commit.Hash)
 		}
+		commit = parent
 	}
 	return commit, nil
 }
 
+// resolveFullHistory walks every commit reachable from `head` since committer
+// times need not decrease along the graph. Ties go to the commit met first.
+func resolveFullHistory(head *object.Commit, time time.Time) (*object.Commit, error) {
+	if !head.Committer.When.After(time) {
+		return head, nil
+	}
+	var latest *object.Commit
+	err := object.NewCommitPreorderIter(head, nil, nil).ForEach(func(c *object.Commit) error {
+		if !c.Committer.When.After(time) && (latest == nil || c.Committer.When.After(latest.Committer.When)) {
+			latest = c
+		}
+		return nil
+	})
+	return latest, errors.WithStack(err)
+}
+
 func CurrentTree(repo *git.Repository, reference string) (*object.Tree, error) {
-	commit, err := ResolveCommit(repo, reference, time.Now())
+	commit, err := ResolveCommit(repo, reference, time.Now(), FirstParent, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -49,33 +115,384 @@ func CurrentTree(repo *git.Repository, reference string) (*object.Tree, error) {
 	return tree, errors.Wrapf(err, "reference `%s`", reference)
 }
 
-func MakeTree(tree *object.Tree, upserts map[string][]byte, deletes []string) (*object.Tree, error) {
+// WriteTree encodes `tree` and stores it, returning the hash of the stored object.
+func WriteTree(s storer.EncodedObjectStorer, tree *object.Tree) (plumbing.Hash, error) {
+	obj := s.NewEncodedObject()
+	if err := tree.Encode(obj); err != nil {
+		return plumbing.ZeroHash, errors.WithStack(err)
+	}
+	hash, err := s.SetEncodedObject(obj)
+	return hash, errors.WithStack(err)
+}
+
+// CreateCommit stores a commit of `tree` on top of `parents` and returns it
+// decoded from the store. The commit is signed with `signKey` unless it is nil.
+func CreateCommit(s storer.EncodedObjectStorer, tree plumbing.Hash, parents []plumbing.Hash, author, committer object.Signature, msg string, signKey *openpgp.Entity) (*object.Commit, error) {
+	commit := &object.Commit{
+		Author:       author,
+		Committer:    committer,
+		Message:      msg,
+		TreeHash:     tree,
+		ParentHashes: parents,
+	}
+	if signKey != nil {
+		sig, err := signCommit(commit, signKey)
+		if err != nil {
+			return nil, err
+		}
+		commit.PGPSignature = sig
+	}
+	obj := s.NewEncodedObject()
+	if err := commit.Encode(obj); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	hash, err := s.SetEncodedObject(obj)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	commit, err = object.GetCommit(s, hash)
+	return commit, errors.Wrapf(err, "hash `%s`", hash)
+}
+
+// HeadCommit returns the commit `reference` currently points at. The cause of
+// the returned error is plumbing.ErrReferenceNotFound if the ref does not exist.
+func HeadCommit(repo *git.Repository, reference string) (*object.Commit, error) {
+	ref, err := repo.Reference(plumbing.ReferenceName(reference), true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "ref `%s`", reference)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	return commit, errors.Wrapf(err, "hash `%s`", ref.Hash())
+}
+
+// UpdateRef points `reference` at `hash` provided it currently points at `old`.
+// A zero `old` requires that the ref does not exist yet. The cause of the
+// returned error is consts.ErrConflict if the ref is not at `old`.
+func UpdateRef(s storer.ReferenceStorer, reference string, hash plumbing.Hash, old plumbing.Hash) error {
+	refName := plumbing.ReferenceName(reference)
+	cur, err := s.Reference(refName)
+	switch {
+	case err == plumbing.ErrReferenceNotFound:
+		if !old.IsZero() {
+			return errors.Wrapf(consts.ErrConflict, "ref `%s` does not exist, expected `%s`", reference, old)
+		}
+		cur = nil
+	case err != nil:
+		return errors.Wrapf(err, "ref `%s`", reference)
+	case cur.Hash() != old:
+		return errors.Wrapf(consts.ErrConflict, "ref `%s` at `%s`, expected `%s`", reference, cur.Hash(), old)
+	}
+	return errors.WithStack(s.CheckAndSetReference(plumbing.NewHashReference(refName, hash), cur))
+}
+
+// Upsert writes `Content` to `Path` as a file of the given mode, zero meaning
+// filemode.Regular. A symlink has its target as content. A filemode.Submodule
+// entry points at the commit `Hash` and has no content.
+type Upsert struct {
+	Path    string
+	Content []byte
+	Mode    filemode.FileMode
+	Hash    plumbing.Hash
+}
+
+func (u *Upsert) change() (*change, error) {
+	switch u.Mode {
+	case filemode.Empty, filemode.Regular:
+		return &change{content: u.Content, mode: filemode.Regular}, nil
+	case filemode.Executable:
+		return &change{content: u.Content, mode: u.Mode}, nil
+	case filemode.Symlink:
+		if len(u.Content) == 0 {
+			return nil, errors.Errorf("symlink `%s` has no target", u.Path)
+		}
+		return &change{content: u.Content, mode: u.Mode}, nil
+	case filemode.Submodule:
+		if u.Hash.IsZero() || len(u.Content) > 0 {
+			return nil, errors.Errorf("gitlink `%s` needs a commit hash and no content", u.Path)
+		}
+		return &change{entry: &object.TreeEntry{Mode: u.Mode, Hash: u.Hash}}, nil
+	default:
+		return nil, errors.Errorf("invalid mode `%s` for path `%s`", u.Mode, u.Path)
+	}
+}
+
+// Move relocates the file or directory at `From` to `To`, reusing its object.
+type Move struct {
+	From string
+	To   string
+}
+
+// change is a pending modification of a single path in MakeTree.
+type change struct {
+	content []byte
+	mode    filemode.FileMode
+	// entry is placed as is instead of writing `content`.
+	entry  *object.TreeEntry
+	delete bool
+}
+
+// Changes are the modifications MakeTree applies to a tree.
+type Changes struct {
+	Upserts []Upsert
+	// Deletes are expanded by ExpandDeletes.
+	Deletes []string
+	// Moves are resolved against the original tree.
+	Moves []Move
+	// Patches apply to the file at their path in the original tree.
+	Patches []Patch
+}
+
+// MakeTree writes the tree obtained by applying `c` to `tree` (nil for an empty
+// tree) and returns it along with the files removed by the deletes. None of
+// the paths of moves and patches may overlap any other path of `c`. Only the
+// trees along modified paths are rewritten, untouched subtrees are reused as
+// is.
+func MakeTree(s storer.EncodedObjectStorer, tree *object.Tree, c *Changes) (*object.Tree, []string, error) {
+	changes := map[string]*change{}
+	for _, upsert := range c.Upserts {
+		p, err := CleanPath(upsert.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, ok := changes[p]; ok {
+			return nil, nil, errors.Errorf("duplicate upsert path `%s`", p)
+		}
+		if changes[p], err = upsert.change(); err != nil {
+			return nil, nil, err
+		}
+	}
+	deleted, err := ExpandDeletes(tree, c.Deletes)
+	if err != nil {
+		return nil, nil, err
+	}
 	// Verify upserts and deletes do not overlap.
-	for upsertPath := range upserts {
-		for _, deletePath := range deletes {
-			if upsertPath == deletePath {
-				return nil, errors.Errorf("overlapping upsert and delete path `%s`", upsertPath)
+	for _, deletePath := range c.Deletes {
+		p, _ := CleanDelete(deletePath)
+		if _, ok := changes[p]; ok {
+			return nil, nil, errors.Errorf("overlapping upsert and delete path `%s`", p)
+		}
+	}
+	for _, p := range deleted {
+		if existing, ok := changes[p]; ok && !existing.delete {
+			return nil, nil, errors.Errorf("overlapping upsert and delete path `%s`", p)
+		}
+		changes[p] = &change{delete: true}
+	}
+	if err := addPatches(s, tree, changes, c.Patches); err != nil {
+		return nil, nil, err
+	}
+	if err := addMoves(tree, changes, c.Moves); err != nil {
+		return nil, nil, err
+	}
+	hash, _, err := updateTree(s, "", tree, changes)
+	if err != nil {
+		return nil, nil, err
+	}
+	newTree, err := object.GetTree(s, hash)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "hash `%s`", hash)
+	}
+	return newTree, deleted, nil
+}
+
+// checkExclusive verifies that the cleaned path `p` overlaps none of the paths
+// in `changes`.
+func checkExclusive(changes map[string]*change, p string) error {
+	for other := range changes {
+		if overlaps(p, other) {
+			return errors.Errorf("path `%s` overlaps `%s`", p, other)
+		}
+	}
+	return nil
+}
+
+// addPatches adds the patched content of every file in `patches` to `changes`.
+func addPatches(s storer.EncodedObjectStorer, tree *object.Tree, changes map[string]*change, patches []Patch) error {
+	for _, patch := range patches {
+		p, err := CleanPath(patch.Path)
+		if err != nil {
+			return err
+		}
+		if err := checkExclusive(changes, p); err != nil {
+			return err
+		}
+		entry, err := FindEntry(tree, p)
+		if err != nil {
+			return err
+		}
+		if entry == nil || (entry.Mode != filemode.Regular && entry.Mode != filemode.Executable) {
+			return &PatchError{Path: p, Reason: "not a file"}
+		}
+		blob, err := object.GetBlob(s, entry.Hash)
+		if err != nil {
+			return errors.Wrapf(err, "path `%s`", p)
+		}
+		reader, err := blob.Reader()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		content, err := ioutil.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		patched, err := patch.apply(content)
+		if err != nil {
+			return err
+		}
+		changes[p] = &change{content: patched, mode: entry.Mode}
+	}
+	return nil
+}
+
+// addMoves adds the source and target of every move in `moves` to `changes`,
+// after checking the move against `tree` and every other changed path.
+func addMoves(tree *object.Tree, changes map[string]*change, moves []Move) error {
+	for _, m := range moves {
+		from, err := CleanPath(m.From)
+		if err != nil {
+			return err
+		}
+		to, err := CleanPath(m.To)
+		if err != nil {
+			return err
+		}
+		if overlaps(from, to) {
+			return errors.Errorf("cannot move `%s` to `%s`", from, to)
+		}
+		for _, p := range []string{from, to} {
+			if err := checkExclusive(changes, p); err != nil {
+				return err
+			}
+		}
+		entry, err := FindEntry(tree, from)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			return errors.Errorf("move source `%s` does not exist", from)
+		}
+		target, err := FindEntry(tree, to)
+		if err != nil {
+			return err
+		}
+		if target != nil {
+			return errors.Errorf("move target `%s` already exists", to)
+		}
+		changes[from] = &change{delete: true}
+		changes[to] = &change{entry: entry}
+	}
+	return nil
+}
+
+// updateTree applies `changes`, keyed by paths relative to `tree`, and writes
+// the resulting tree. It returns the new hash and the number of entries, where
+// an empty tree should be dropped from its parent.
+func updateTree(s storer.EncodedObjectStorer, prefix string, tree *object.Tree, changes map[string]*change) (plumbing.Hash, int, error) {
+	entries := map[string]object.TreeEntry{}
+	if tree != nil {
+		for _, entry := range tree.Entries {
+			entries[entry.Name] = entry
+		}
+	}
+	nested := map[string]map[string]*change{}
+	for p, c := range changes {
+		if i := strings.Index(p, "/"); i >= 0 {
+			dir := p[:i]
+			if nested[dir] == nil {
+				nested[dir] = map[string]*change{}
 			}
+			nested[dir][p[i+1:]] = c
+			continue
+		}
+		entry, exists := entries[p]
+		if exists && entry.Mode == filemode.Dir && !c.delete {
+			return plumbing.ZeroHash, 0, errors.Errorf("path `%s` is a directory", path.Join(prefix, p))
+		}
+		if c.delete {
+			delete(entries, p)
+			continue
+		}
+		if c.entry != nil {
+			entries[p] = object.TreeEntry{Name: p, Mode: c.entry.Mode, Hash: c.entry.Hash}
+			continue
 		}
+		hash, err := writeBlob(s, c.content)
+		if err != nil {
+			return plumbing.ZeroHash, 0, err
+		}
+		entries[p] = object.TreeEntry{Name: p, Mode: c.mode, Hash: hash}
 	}
+	for dir, sub := range nested {
+		var subtree *object.Tree
+		if entry, ok := entries[dir]; ok {
+			if entry.Mode != filemode.Dir {
+				return plumbing.ZeroHash, 0, errors.Errorf("path `%s` is a file", path.Join(prefix, dir))
+			}
+			var err error
+			if subtree, err = object.GetTree(s, entry.Hash); err != nil {
+				return plumbing.ZeroHash, 0, errors.Wrapf(err, "path `%s`", path.Join(prefix, dir))
+			}
+		}
+		hash, n, err := updateTree(s, path.Join(prefix, dir), subtree, sub)
+		if err != nil {
+			return plumbing.ZeroHash, 0, err
+		}
+		if n == 0 {
+			delete(entries, dir)
+		} else {
+			entries[dir] = object.TreeEntry{Name: dir, Mode: filemode.Dir, Hash: hash}
+		}
+	}
+	newTree := &object.Tree{}
+	for _, entry := range entries {
+		newTree.Entries = append(newTree.Entries, entry)
+	}
+	sort.Slice(newTree.Entries, func(i, j int) bool {
+		return entrySortKey(newTree.Entries[i]) < entrySortKey(newTree.Entries[j])
+	})
+	hash, err := WriteTree(s, newTree)
+	return hash, len(newTree.Entries), err
+}
 
-	// newAllPaths will hold all paths in the new tree.
-	newAllPaths := map[string]struct{}{}
-	// 1) Add all existing paths from the old tree.
-	if err := tree.Files().ForEach(func(file *object.File) error {
-		newAllPaths[path.Clean(file.Name)] = struct{}{}
-		return nil
-	}); err != nil {
-		return nil, errors.WithStack(err)
+// entrySortKey orders entries the way git does, comparing directories as if
+// their name had a trailing slash.
+func entrySortKey(entry object.TreeEntry) string {
+	if entry.Mode == filemode.Dir {
+		return entry.Name + "/"
 	}
-	// 2) Add all paths from upserts.
-	for upsertPath := range upserts {
-		newAllPaths[path.Clean(upsertPath)] = struct{}{}
+	return entry.Name
+}
+
+// CleanPath normalizes a file path and rejects paths that escape the tree.
+func CleanPath(p string) (string, error) {
+	cleaned := path.Clean(p)
+	if cleaned == "." || path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", errors.Errorf("invalid path `%s`", p)
 	}
-	// 3) Delete all paths from deletes.
-	for _, deletePath := range deletes {
-		delete(newAllPaths, path.Clean(deletePath))
+	for _, part := range strings.Split(cleaned, "/") {
+		if part == ".git" {
+			return "", errors.Errorf("invalid path `%s`", p)
+		}
 	}
+	return cleaned, nil
+}
 
-	return nil, consts.ErrNYI
+func writeBlob(s storer.EncodedObjectStorer, content []byte) (plumbing.Hash, error) {
+	obj := s.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	obj.SetSize(int64(len(content)))
+	w, err := obj.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, errors.WithStack(err)
+	}
+	if _, err := w.Write(content); err != nil {
+		w.Close()
+		return plumbing.ZeroHash, errors.WithStack(err)
+	}
+	if err := w.Close(); err != nil {
+		return plumbing.ZeroHash, errors.WithStack(err)
+	}
+	hash, err := s.SetEncodedObject(obj)
+	return hash, errors.WithStack(err)
 }
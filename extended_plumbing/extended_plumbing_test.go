@@ -0,0 +1,197 @@
+package extended_plumbing
+
+import (
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// makeFiles writes a tree holding `files`, mapped to their content.
+func makeFiles(t *testing.T, s *memory.Storage, files map[string]string) *object.Tree {
+	c := &Changes{}
+	for p, content := range files {
+		c.Upserts = append(c.Upserts, Upsert{Path: p, Content: []byte(content)})
+	}
+	tree, _, err := MakeTree(s, nil, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+// readFiles maps every file of `tree` to its content.
+func readFiles(t *testing.T, tree *object.Tree) map[string]string {
+	files := map[string]string{}
+	iter := tree.Files()
+	for {
+		f, err := iter.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := f.Contents()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = content
+	}
+}
+
+func TestMakeTree(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		changes *Changes
+		want    map[string]string
+		deleted []string
+		wantErr string
+	}{
+		{
+			name:    "create under a missing directory",
+			files:   map[string]string{"a": "1"},
+			changes: &Changes{Upserts: []Upsert{{Path: "x/y/z", Content: []byte("2")}}},
+			want:    map[string]string{"a": "1", "x/y/z": "2"},
+		},
+		{
+			name:    "update next to other files",
+			files:   map[string]string{"d/a": "1", "d/b": "2", "e/c": "3"},
+			changes: &Changes{Upserts: []Upsert{{Path: "d/a", Content: []byte("4")}}},
+			want:    map[string]string{"d/a": "4", "d/b": "2", "e/c": "3"},
+		},
+		{
+			name:    "delete the last file of nested directories",
+			files:   map[string]string{"a": "1", "d/e/f": "2"},
+			changes: &Changes{Deletes: []string{"d/e/f"}},
+			want:    map[string]string{"a": "1"},
+			deleted: []string{"d/e/f"},
+		},
+		{
+			name:    "delete a directory",
+			files:   map[string]string{"d/x": "1", "d/y/z": "2", "e": "3"},
+			changes: &Changes{Deletes: []string{"d"}},
+			want:    map[string]string{"e": "3"},
+			deleted: []string{"d/x", "d/y/z"},
+		},
+		{
+			name:    "delete everything",
+			files:   map[string]string{"d/x": "1"},
+			changes: &Changes{Deletes: []string{"d/x"}},
+			want:    map[string]string{},
+			deleted: []string{"d/x"},
+		},
+		{
+			name:    "delete a missing path",
+			files:   map[string]string{"a": "1"},
+			changes: &Changes{Deletes: []string{"b"}},
+			want:    map[string]string{"a": "1"},
+		},
+		{
+			name:    "file where a directory is",
+			files:   map[string]string{"d/x": "1"},
+			changes: &Changes{Upserts: []Upsert{{Path: "d", Content: []byte("2")}}},
+			wantErr: "path `d` is a directory",
+		},
+		{
+			name:    "directory where a file is",
+			files:   map[string]string{"d/x": "1"},
+			changes: &Changes{Upserts: []Upsert{{Path: "d/x/y", Content: []byte("2")}}},
+			wantErr: "path `d/x` is a file",
+		},
+		{
+			name:  "file and directory in the same write",
+			files: map[string]string{},
+			changes: &Changes{Upserts: []Upsert{
+				{Path: "a", Content: []byte("1")},
+				{Path: "a/b", Content: []byte("2")},
+			}},
+			wantErr: "path `a` is a file",
+		},
+		{
+			name:    "upsert and delete of the same path",
+			files:   map[string]string{"a": "1"},
+			changes: &Changes{Upserts: []Upsert{{Path: "a", Content: []byte("2")}}, Deletes: []string{"a"}},
+			wantErr: "overlapping upsert and delete path `a`",
+		},
+		{
+			name:    "upsert into a deleted directory",
+			files:   map[string]string{"d/x": "1"},
+			changes: &Changes{Upserts: []Upsert{{Path: "d/x", Content: []byte("2")}}, Deletes: []string{"d"}},
+			wantErr: "overlapping upsert and delete path `d/x`",
+		},
+		{
+			name:    "duplicate upsert",
+			files:   map[string]string{},
+			changes: &Changes{Upserts: []Upsert{{Path: "a"}, {Path: "a"}}},
+			wantErr: "duplicate upsert path `a`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := memory.NewStorage()
+			tree := makeFiles(t, s, tt.files)
+			newTree, deleted, err := MakeTree(s, tree, tt.changes)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := readFiles(t, newTree); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got files %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(deleted, tt.deleted) {
+				t.Errorf("got deleted %v, want %v", deleted, tt.deleted)
+			}
+			// Emptied directories are pruned rather than kept as empty trees.
+			walker := object.NewTreeWalker(newTree, true, nil)
+			defer walker.Close()
+			for {
+				name, entry, err := walker.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !entry.Mode.IsFile() {
+					sub, err := newTree.Tree(name)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if len(sub.Entries) == 0 {
+						t.Errorf("empty directory `%s` was kept", name)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMakeTreeNoop(t *testing.T) {
+	s := memory.NewStorage()
+	tree := makeFiles(t, s, map[string]string{"a": "1", "d/b": "2"})
+	for _, c := range []*Changes{
+		{},
+		{Upserts: []Upsert{{Path: "d/b", Content: []byte("2")}}},
+		{Deletes: []string{"missing", "d/missing", "*.json"}},
+	} {
+		newTree, deleted, err := MakeTree(s, tree, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if newTree.Hash != tree.Hash {
+			t.Errorf("changes %+v rewrote tree `%s` to `%s`", c, tree.Hash, newTree.Hash)
+		}
+		if len(deleted) != 0 {
+			t.Errorf("changes %+v deleted %v", c, deleted)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package extended_plumbing
+
+import (
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+)
+
+// overlay keeps the objects stored in it in memory, on top of the objects of
+// `base`, which it never writes to.
+type overlay struct {
+	base storer.EncodedObjectStorer
+	mem  *memory.Storage
+}
+
+// NewOverlay returns a storer that reads from `s` but keeps new objects in
+// memory, to build trees without persisting them.
+func NewOverlay(s storer.EncodedObjectStorer) storer.EncodedObjectStorer {
+	return &overlay{base: s, mem: memory.NewStorage()}
+}
+
+func (o *overlay) NewEncodedObject() plumbing.EncodedObject {
+	return o.mem.NewEncodedObject()
+}
+
+func (o *overlay) SetEncodedObject(obj plumbing.EncodedObject) (plumbing.Hash, error) {
+	return o.mem.SetEncodedObject(obj)
+}
+
+func (o *overlay) EncodedObject(t plumbing.ObjectType, h plumbing.Hash) (plumbing.EncodedObject, error) {
+	obj, err := o.mem.EncodedObject(t, h)
+	if err == plumbing.ErrObjectNotFound {
+		return o.base.EncodedObject(t, h)
+	}
+	return obj, err
+}
+
+func (o *overlay) IterEncodedObjects(t plumbing.ObjectType) (storer.EncodedObjectIter, error) {
+	mem, err := o.mem.IterEncodedObjects(t)
+	if err != nil {
+		return nil, err
+	}
+	base, err := o.base.IterEncodedObjects(t)
+	if err != nil {
+		return nil, err
+	}
+	return storer.NewMultiEncodedObjectIter([]storer.EncodedObjectIter{mem, base}), nil
+}
+
+func (o *overlay) HasEncodedObject(h plumbing.Hash) error {
+	if err := o.mem.HasEncodedObject(h); err != plumbing.ErrObjectNotFound {
+		return err
+	}
+	return o.base.HasEncodedObject(h)
+}
+
+func (o *overlay) EncodedObjectSize(h plumbing.Hash) (int64, error) {
+	size, err := o.mem.EncodedObjectSize(h)
+	if err == plumbing.ErrObjectNotFound {
+		return o.base.EncodedObjectSize(h)
+	}
+	return size, err
+}
@@ -0,0 +1,295 @@
+package extended_plumbing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type PatchType int
+
+const (
+	// MergePatch is an RFC 7386 JSON merge patch.
+	MergePatch PatchType = iota
+	// JSONPatch is an RFC 6902 JSON patch.
+	JSONPatch
+	// UnifiedDiff is a unified diff of a single file, applied without fuzz.
+	UnifiedDiff
+)
+
+// Patch modifies the current content of the file at `Path`.
+type Patch struct {
+	Path    string
+	Type    PatchType
+	Content []byte
+}
+
+// PatchError is returned when a patch does not apply to the current content.
+type PatchError struct {
+	Path   string
+	Reason string
+}
+
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("patch of `%s` does not apply: %s", e.Path, e.Reason)
+}
+
+func (p *Patch) apply(content []byte) ([]byte, error) {
+	var patched []byte
+	var err error
+	switch p.Type {
+	case MergePatch, JSONPatch:
+		patched, err = p.applyJSON(content)
+	case UnifiedDiff:
+		patched, err = applyUnifiedDiff(content, p.Content)
+	default:
+		return nil, errors.Errorf("unrecognized patch type `%d` for path `%s`", p.Type, p.Path)
+	}
+	if err != nil {
+		return nil, &PatchError{Path: p.Path, Reason: err.Error()}
+	}
+	return patched, nil
+}
+
+// applyJSON applies a merge patch or JSON patch. Files ending in `.yaml` or
+// `.yml` are converted to JSON for the patch and back to YAML afterwards, any
+// other file must be JSON. The patched document is re-encoded with its keys
+// sorted, so other formatting than the indentation of JSON and a trailing
+// newline is lost, and so are YAML comments.
+func (p *Patch) applyJSON(content []byte) ([]byte, error) {
+	isYAML := path.Ext(p.Path) == ".yaml" || path.Ext(p.Path) == ".yml"
+	doc := content
+	if isYAML {
+		var v interface{}
+		if err := yaml.Unmarshal(content, &v); err != nil {
+			return nil, errors.Errorf("malformed YAML: %s", err)
+		}
+		var err error
+		if doc, err = json.Marshal(JSONCompatible(v)); err != nil {
+			return nil, errors.Errorf("YAML cannot be converted to JSON: %s", err)
+		}
+	} else if !json.Valid(content) {
+		return nil, errors.Errorf("JSON patches only apply to JSON files and YAML files ending in `.yaml` or `.yml`")
+	}
+
+	var patched []byte
+	var err error
+	if p.Type == MergePatch {
+		patched, err = jsonpatch.MergePatch(doc, p.Content)
+	} else {
+		var ops jsonpatch.Patch
+		if ops, err = jsonpatch.DecodePatch(p.Content); err == nil {
+			patched, err = ops.Apply(doc)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if isYAML {
+		d := json.NewDecoder(bytes.NewReader(patched))
+		d.UseNumber()
+		var v interface{}
+		if err := d.Decode(&v); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		out, err := yaml.Marshal(yamlNumbers(v))
+		return out, errors.WithStack(err)
+	}
+	if indent := jsonIndent(content); indent != "" {
+		var b bytes.Buffer
+		if err := json.Indent(&b, patched, "", indent); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		patched = b.Bytes()
+	}
+	if bytes.HasSuffix(content, []byte("\n")) && !bytes.HasSuffix(patched, []byte("\n")) {
+		patched = append(patched, '\n')
+	}
+	return patched, nil
+}
+
+// jsonIndent guesses the indentation of a JSON document from its first indented
+// line. It is empty for compact documents.
+func jsonIndent(content []byte) string {
+	for _, line := range strings.Split(string(content), "\n")[1:] {
+		if indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]; indent != "" {
+			return indent
+		}
+	}
+	return ""
+}
+
+// JSONCompatible converts the maps decoded from YAML, which may have keys of
+// any type, to maps with string keys.
+func JSONCompatible(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, e := range v {
+			m[fmt.Sprint(k)] = JSONCompatible(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range v {
+			v[i] = JSONCompatible(e)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// yamlNumbers converts the json.Numbers of a decoded document to integers where
+// possible, which YAML would otherwise quote as strings.
+func yamlNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = yamlNumbers(e)
+		}
+		return v
+	case []interface{}:
+		for i, e := range v {
+			v[i] = yamlNumbers(e)
+		}
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
+
+var hunkHeaderRegexp = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// hunk is a parsed hunk of a unified diff. Every line keeps its newline,
+// except for a last line without one.
+type hunk struct {
+	oldStart, oldCount int
+	newCount           int
+	lines              []string
+}
+
+// applyUnifiedDiff applies the hunks of `diff` at exactly the lines they name,
+// failing if any context or removed line differs from `content`.
+func applyUnifiedDiff(content []byte, diff []byte) ([]byte, error) {
+	hunks, err := parseHunks(string(diff))
+	if err != nil {
+		return nil, err
+	}
+	old := strings.SplitAfter(string(content), "\n")
+	if old[len(old)-1] == "" {
+		old = old[:len(old)-1]
+	}
+	var b strings.Builder
+	pos := 0
+	for _, h := range hunks {
+		start := h.oldStart - 1
+		if h.oldCount == 0 {
+			start = h.oldStart
+		}
+		if start < pos || start > len(old) {
+			return nil, errors.Errorf("hunk at line %d out of range", h.oldStart)
+		}
+		for _, line := range old[pos:start] {
+			b.WriteString(line)
+		}
+		pos = start
+		for _, line := range h.lines {
+			op, text := line[0], line[1:]
+			if op == '+' {
+				b.WriteString(text)
+				continue
+			}
+			if pos >= len(old) || old[pos] != text {
+				return nil, errors.Errorf("hunk at line %d does not match line %d", h.oldStart, pos+1)
+			}
+			if op == ' ' {
+				b.WriteString(text)
+			}
+			pos++
+		}
+	}
+	for _, line := range old[pos:] {
+		b.WriteString(line)
+	}
+	return []byte(b.String()), nil
+}
+
+func parseHunks(diff string) ([]*hunk, error) {
+	lines := strings.SplitAfter(diff, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	var hunks []*hunk
+	for i := 0; i < len(lines); i++ {
+		m := hunkHeaderRegexp.FindStringSubmatch(lines[i])
+		if m == nil {
+			if len(hunks) == 0 || strings.TrimSpace(lines[i]) == "" {
+				// File headers before the first hunk, trailing blank lines.
+				continue
+			}
+			return nil, errors.Errorf("unexpected diff line `%s`", strings.TrimRight(lines[i], "\n"))
+		}
+		h := &hunk{oldCount: 1, newCount: 1}
+		h.oldStart, _ = strconv.Atoi(m[1])
+		if m[2] != "" {
+			h.oldCount, _ = strconv.Atoi(m[2])
+		}
+		if m[4] != "" {
+			h.newCount, _ = strconv.Atoi(m[4])
+		}
+		oldLeft, newLeft := h.oldCount, h.newCount
+		for i+1 < len(lines) && (oldLeft > 0 || newLeft > 0 || strings.HasPrefix(lines[i+1], "\\")) {
+			i++
+			line := lines[i]
+			switch {
+			case strings.HasPrefix(line, "\\"):
+				// "\ No newline at end of file" applies to the previous line.
+				if len(h.lines) == 0 {
+					return nil, errors.Errorf("unexpected diff line `%s`", strings.TrimRight(line, "\n"))
+				}
+				h.lines[len(h.lines)-1] = strings.TrimSuffix(h.lines[len(h.lines)-1], "\n")
+				continue
+			case line == "\n":
+				// Some tools drop the space of empty context lines.
+				line = " \n"
+			}
+			switch line[0] {
+			case ' ':
+				oldLeft--
+				newLeft--
+			case '-':
+				oldLeft--
+			case '+':
+				newLeft--
+			default:
+				return nil, errors.Errorf("unexpected diff line `%s`", strings.TrimRight(line, "\n"))
+			}
+			if !strings.HasSuffix(line, "\n") {
+				line += "\n"
+			}
+			h.lines = append(h.lines, line)
+		}
+		if oldLeft != 0 || newLeft != 0 {
+			return nil, errors.Errorf("truncated hunk at line %d", h.oldStart)
+		}
+		hunks = append(hunks, h)
+	}
+	if len(hunks) == 0 {
+		return nil, errors.Errorf("no hunks in diff")
+	}
+	return hunks, nil
+}
@@ -0,0 +1,184 @@
+package extended_plumbing
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		diff    string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "multiple hunks",
+			content: "a\nb\nc\nd\ne\nf\ng\n",
+			diff: "--- a/f\n+++ b/f\n" +
+				"@@ -1,2 +1,2 @@\n-a\n+A\n b\n" +
+				"@@ -5,3 +5,4 @@\n e\n-f\n+F\n+F2\n g\n",
+			want: "A\nb\nc\nd\ne\nF\nF2\ng\n",
+		},
+		{
+			name:    "pure insertion",
+			content: "a\nb\n",
+			diff:    "@@ -1,0 +2,2 @@\n+x\n+y\n",
+			want:    "a\nx\ny\nb\n",
+		},
+		{
+			name:    "insertion at the start",
+			content: "a\n",
+			diff:    "@@ -0,0 +1 @@\n+x\n",
+			want:    "x\na\n",
+		},
+		{
+			name:    "no newline on both sides",
+			content: "a\nb",
+			diff:    "@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n",
+			want:    "a\nc",
+		},
+		{
+			name:    "newline added",
+			content: "a",
+			diff:    "@@ -1 +1 @@\n-a\n\\ No newline at end of file\n+a\n",
+			want:    "a\n",
+		},
+		{
+			name:    "empty original content",
+			content: "",
+			diff:    "--- /dev/null\n+++ b/f\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+			want:    "a\nb\n",
+		},
+		{
+			name:    "context mismatch",
+			content: "a\nb\nc\n",
+			diff:    "@@ -1,3 +1,3 @@\n a\n-x\n+y\n c\n",
+			wantErr: "does not match line 2",
+		},
+		{
+			name:    "missing newline mismatch",
+			content: "a\n",
+			diff:    "@@ -1 +1 @@\n-a\n\\ No newline at end of file\n+b\n",
+			wantErr: "does not match line 1",
+		},
+		{
+			name:    "overlapping hunks",
+			content: "a\nb\nc\nd\n",
+			diff:    "@@ -1,2 +1,2 @@\n a\n-b\n+B\n@@ -2,2 +2,2 @@\n b\n-c\n+C\n",
+			wantErr: "out of range",
+		},
+		{
+			name:    "hunks out of order",
+			content: "a\nb\nc\nd\n",
+			diff:    "@@ -4 +4 @@\n-d\n+D\n@@ -1 +1 @@\n-a\n+A\n",
+			wantErr: "out of range",
+		},
+		{
+			name:    "hunk past the end",
+			content: "a\n",
+			diff:    "@@ -3 +3 @@\n-c\n+C\n",
+			wantErr: "out of range",
+		},
+		{
+			name:    "truncated hunk",
+			content: "a\nb\n",
+			diff:    "@@ -1,2 +1,2 @@\n-a\n+A\n",
+			wantErr: "truncated hunk",
+		},
+		{
+			name:    "no hunks",
+			content: "a\n",
+			diff:    "--- a/f\n+++ b/f\n",
+			wantErr: "no hunks",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyUnifiedDiff([]byte(tt.content), []byte(tt.diff))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   Patch
+		content string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "merge patch on compact JSON",
+			patch:   Patch{Path: "a.json", Type: MergePatch, Content: []byte(`{"b":2}`)},
+			content: `{"a":1}`,
+			want:    `{"a":1,"b":2}`,
+		},
+		{
+			name:    "JSON patch keeps indentation and trailing newline",
+			patch:   Patch{Path: "a.json", Type: JSONPatch, Content: []byte(`[{"op":"add","path":"/b","value":[1]}]`)},
+			content: "{\n    \"a\": 1\n}\n",
+			want:    "{\n    \"a\": 1,\n    \"b\": [\n        1\n    ]\n}\n",
+		},
+		{
+			name:    "merge patch on YAML",
+			patch:   Patch{Path: "dir/a.yaml", Type: MergePatch, Content: []byte(`{"b":{"c":3},"d":null}`)},
+			content: "# comment\na: 1\nd: x\n",
+			want:    "a: 1\nb:\n  c: 3\n",
+		},
+		{
+			name:    "JSON patch on YAML",
+			patch:   Patch{Path: "a.yml", Type: JSONPatch, Content: []byte(`[{"op":"replace","path":"/a/0","value":"z"}]`)},
+			content: "a: [x, 2.5]\n",
+			want:    "a:\n- z\n- 2.5\n",
+		},
+		{
+			name:    "not JSON",
+			patch:   Patch{Path: "a.txt", Type: MergePatch, Content: []byte(`{"b":2}`)},
+			content: "a: 1\n",
+			wantErr: "only apply to JSON files",
+		},
+		{
+			name:    "malformed YAML",
+			patch:   Patch{Path: "a.yaml", Type: MergePatch, Content: []byte(`{"b":2}`)},
+			content: "a: [\n",
+			wantErr: "malformed YAML",
+		},
+		{
+			name:    "failing JSON patch",
+			patch:   Patch{Path: "a.json", Type: JSONPatch, Content: []byte(`[{"op":"test","path":"/a","value":2}]`)},
+			content: `{"a":1}`,
+			wantErr: "does not apply",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.patch.apply([]byte(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package extended_plumbing
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"strings"
+)
+
+// Precondition requires `Path` to currently be a file with blob hash `Hash`, or
+// not to exist at all if `Hash` is zero.
+type Precondition struct {
+	Path string
+	Hash plumbing.Hash
+}
+
+// Violation is a precondition that does not hold. `Actual` is zero if the path
+// does not exist.
+type Violation struct {
+	Path     string
+	Expected plumbing.Hash
+	Actual   plumbing.Hash
+}
+
+func (v *Violation) String() string {
+	switch {
+	case v.Expected.IsZero():
+		return fmt.Sprintf("path `%s` exists at `%s`, expected absent", v.Path, v.Actual)
+	case v.Actual.IsZero():
+		return fmt.Sprintf("path `%s` does not exist, expected `%s`", v.Path, v.Expected)
+	default:
+		return fmt.Sprintf("path `%s` at `%s`, expected `%s`", v.Path, v.Actual, v.Expected)
+	}
+}
+
+// PreconditionError lists every violated precondition of a write.
+type PreconditionError struct {
+	Violations []*Violation
+}
+
+func (e *PreconditionError) Error() string {
+	var msgs []string
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.String())
+	}
+	return "precondition failed: " + strings.Join(msgs, "; ")
+}
+
+// CheckPreconditions evaluates `preconditions` against `tree` (nil for an empty
+// tree). It returns a *PreconditionError if any of them is violated.
+func CheckPreconditions(tree *object.Tree, preconditions []Precondition) error {
+	var violations []*Violation
+	for _, precondition := range preconditions {
+		p, err := CleanPath(precondition.Path)
+		if err != nil {
+			return err
+		}
+		entry, err := FindEntry(tree, p)
+		if err != nil {
+			return err
+		}
+		var actual plumbing.Hash
+		if entry != nil {
+			actual = entry.Hash
+		}
+		if actual != precondition.Hash || (entry != nil && entry.Mode == filemode.Dir) {
+			violations = append(violations, &Violation{Path: p, Expected: precondition.Hash, Actual: actual})
+		}
+	}
+	if len(violations) > 0 {
+		return &PreconditionError{Violations: violations}
+	}
+	return nil
+}
+
+// FindEntry looks up the entry at the cleaned path `p`, returning nil if there
+// is no such entry.
+func FindEntry(tree *object.Tree, p string) (*object.TreeEntry, error) {
+	if tree == nil {
+		return nil, nil
+	}
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		entry, err := tree.FindEntry(part)
+		if err == object.ErrEntryNotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "path `%s`", p)
+		}
+		if i == len(parts)-1 {
+			return entry, nil
+		}
+		if entry.Mode != filemode.Dir {
+			return nil, nil
+		}
+		if tree, err = tree.Tree(part); err != nil {
+			return nil, errors.Wrapf(err, "path `%s`", p)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,159 @@
+package extended_plumbing
+
+import (
+	"fmt"
+	"github.com/fiibbb/gitdb/consts"
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// minShortHash is the shortest prefix accepted for a commit hash, since
+// expanding a prefix scans every commit of the repo.
+const minShortHash = 7
+
+var (
+	// atTime matches `<ref>@{<time>}<suffix>`.
+	atTime = regexp.MustCompile(`^([^@]*)@\{([^}]*)\}(.*)$`)
+	// hexHash matches hashes regardless of case, like git.
+	hexHash = regexp.MustCompile(`^(?i)[0-9a-f]+$`)
+)
+
+// ResolveRevision finds the object `rev` names. Besides what go-git's revision
+// parser understands, e.g. refs, tags and full commit hashes followed by `~N`
+// and `^N`, it accepts
+//
+//   - `<ref>@{<RFC 3339 time>}`, the commit ResolveCommit finds for the ref
+//     and time with `traversal`, optionally followed by `~N` and `^N`,
+//   - short commit hashes of at least 7 characters,
+//   - hashes of trees and blobs, which resolve to the object itself.
+//
+// Annotated tags resolve to the object they tag. The cause of the returned
+// error is consts.ErrNotFound if `rev` names nothing.
+func ResolveRevision(repo *git.Repository, rev string, traversal Traversal, index *TimeIndex) (object.Object, error) {
+	if m := atTime.FindStringSubmatch(rev); m != nil {
+		t, err := time.Parse(time.RFC3339, m[2])
+		if err != nil {
+			return nil, errors.Errorf("revision `%s`: `%s` is not an RFC 3339 time", rev, m[2])
+		}
+		refName, err := expandRef(repo, m[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "revision `%s`", rev)
+		}
+		commit, err := ResolveCommit(repo, refName.String(), t, traversal, index)
+		if err != nil {
+			return nil, err
+		}
+		if m[3] == "" {
+			return commit, nil
+		}
+		rev = commit.Hash.String() + m[3]
+	}
+	if strings.Contains(rev, ":") {
+		// go-git parses `<rev>:<path>` but ignores the path.
+		return nil, errors.Errorf("revision `%s`: paths are not supported in revisions", rev)
+	}
+
+	base, suffix := rev, ""
+	if i := strings.IndexAny(rev, "~^"); i >= 0 {
+		base, suffix = rev[:i], rev[i:]
+	}
+	if isShortHash(base) {
+		if _, err := expandRef(repo, base); err != nil {
+			hash, err := expandHash(repo.Storer, base)
+			if err != nil {
+				return nil, errors.Wrapf(err, "revision `%s`", rev)
+			}
+			base = hash.String()
+		}
+	}
+	if suffix == "" && len(base) == len(plumbing.ZeroHash.String()) && hexHash.MatchString(base) {
+		if _, err := expandRef(repo, base); err != nil {
+			return resolveObject(repo, plumbing.NewHash(base))
+		}
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(base + suffix))
+	// go-git fails with io.EOF if `~N` walks past the root commit.
+	if err == plumbing.ErrReferenceNotFound || err == plumbing.ErrObjectNotFound || err == io.EOF {
+		return nil, errors.Wrapf(consts.ErrNotFound, "revision `%s`", rev)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "revision `%s`", rev)
+	}
+	commit, err := repo.CommitObject(*hash)
+	return commit, errors.Wrapf(err, "hash `%s`", hash)
+}
+
+// expandRef finds the ref `name` abbreviates following git's rules, e.g.
+// `master` is `refs/heads/master`. An empty name is the master branch.
+func expandRef(repo *git.Repository, name string) (plumbing.ReferenceName, error) {
+	if name == "" {
+		return consts.RefNameMaster, nil
+	}
+	for _, rule := range append([]string{"%s"}, plumbing.RefRevParseRules...) {
+		refName := plumbing.ReferenceName(fmt.Sprintf(rule, name))
+		if _, err := repo.Storer.Reference(refName); err == nil {
+			return refName, nil
+		}
+	}
+	return "", errors.Wrapf(consts.ErrNotFound, "ref `%s`", name)
+}
+
+func isShortHash(s string) bool {
+	return len(s) >= minShortHash && len(s) < len(plumbing.ZeroHash.String()) && hexHash.MatchString(s)
+}
+
+// expandHash finds the commit whose hash starts with `prefix`. go-git cannot
+// look up objects by prefix, so it iterates all commits of the repo.
+func expandHash(s storer.EncodedObjectStorer, prefix string) (plumbing.Hash, error) {
+	iter, err := s.IterEncodedObjects(plumbing.CommitObject)
+	if err != nil {
+		return plumbing.ZeroHash, errors.WithStack(err)
+	}
+	prefix = strings.ToLower(prefix)
+	var found []plumbing.Hash
+	err = iter.ForEach(func(obj plumbing.EncodedObject) error {
+		if strings.HasPrefix(obj.Hash().String(), prefix) {
+			found = append(found, obj.Hash())
+		}
+		return nil
+	})
+	if err != nil {
+		return plumbing.ZeroHash, errors.WithStack(err)
+	}
+	switch len(found) {
+	case 0:
+		return plumbing.ZeroHash, errors.Wrapf(consts.ErrNotFound, "short hash `%s`", prefix)
+	case 1:
+		return found[0], nil
+	default:
+		return plumbing.ZeroHash, errors.Errorf("short hash `%s` is ambiguous", prefix)
+	}
+}
+
+// resolveObject returns the object `hash` names, peeling annotated tags.
+func resolveObject(repo *git.Repository, hash plumbing.Hash) (object.Object, error) {
+	obj, err := repo.Object(plumbing.AnyObject, hash)
+	if err == plumbing.ErrObjectNotFound {
+		return nil, errors.Wrapf(consts.ErrNotFound, "hash `%s`", hash)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "hash `%s`", hash)
+	}
+	for {
+		tag, ok := obj.(*object.Tag)
+		if !ok {
+			return obj, nil
+		}
+		if obj, err = tag.Object(); err != nil {
+			return nil, errors.Wrapf(err, "tag `%s`", tag.Hash)
+		}
+	}
+}
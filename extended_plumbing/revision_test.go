@@ -0,0 +1,69 @@
+package extended_plumbing
+
+import (
+	"github.com/fiibbb/gitdb/consts"
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolveRevision(t *testing.T) {
+	repo := newMemRepo(t)
+	base := time.Unix(1500000000, 0)
+	a := commitOn(t, repo, plumbing.ZeroHash, "a", base)
+	b := commitOn(t, repo, a, "b", base.Add(time.Hour))
+	tree, err := WriteTree(repo.Storer, &object.Tree{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		rev     string
+		want    plumbing.Hash
+		wantErr error
+	}{
+		{rev: "master", want: b},
+		{rev: "master~1", want: a},
+		{rev: "master^", want: a},
+		{rev: "master@{" + base.Add(time.Minute).Format(time.RFC3339) + "}", want: a},
+		{rev: "master@{" + base.Add(2*time.Hour).Format(time.RFC3339) + "}~1", want: a},
+		{rev: a.String()[:7], want: a},
+		{rev: b.String()[:10] + "~1", want: a},
+		{rev: tree.String(), want: tree},
+		{rev: strings.ToUpper(a.String()[:7]), want: a},
+		{rev: strings.ToUpper(b.String()[:10]) + "~1", want: a},
+		{rev: strings.ToUpper(tree.String()), want: tree},
+		// Past the root commit.
+		{rev: "master~2", wantErr: consts.ErrNotFound},
+		{rev: "master@{" + base.Add(-time.Minute).Format(time.RFC3339) + "}", wantErr: consts.ErrNotFound},
+		// Too short to expand.
+		{rev: a.String()[:6], wantErr: consts.ErrNotFound},
+		// Only commit hashes are expanded.
+		{rev: tree.String()[:7], wantErr: consts.ErrNotFound},
+		{rev: "missing", wantErr: consts.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rev, func(t *testing.T) {
+			obj, err := ResolveRevision(repo, tt.rev, FirstParent, nil)
+			if tt.wantErr != nil {
+				if errors.Cause(err) != tt.wantErr {
+					t.Errorf("got %v, %v, want error %v", obj, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if obj.ID() != tt.want {
+				t.Errorf("got %s, want %s", obj.ID(), tt.want)
+			}
+		})
+	}
+
+	if _, err := ResolveRevision(repo, "master:a", FirstParent, nil); err == nil || errors.Cause(err) == consts.ErrNotFound {
+		t.Errorf("got error %v for a path, want a rejection", err)
+	}
+}
@@ -0,0 +1,134 @@
+package extended_plumbing
+
+import (
+	"bytes"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// ReadSigningKey loads the first entity of the armored OpenPGP key ring at
+// `path`. The entity must carry an unencrypted private key.
+func ReadSigningKey(path string) (*openpgp.Entity, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer f.Close()
+	entities, err := openpgp.ReadArmoredKeyRing(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signing key `%s`", path)
+	}
+	if len(entities) == 0 {
+		return nil, errors.Errorf("signing key `%s` contains no keys", path)
+	}
+	entity := entities[0]
+	if entity.PrivateKey == nil {
+		return nil, errors.Errorf("signing key `%s` contains no private key", path)
+	}
+	if entity.PrivateKey.Encrypted {
+		return nil, errors.Errorf("signing key `%s` is encrypted", path)
+	}
+	return entity, nil
+}
+
+// ReadKeyRing loads the armored OpenPGP public keys at `path`.
+func ReadKeyRing(path string) (openpgp.EntityList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer f.Close()
+	entities, err := openpgp.ReadArmoredKeyRing(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "key ring `%s`", path)
+	}
+	return entities, nil
+}
+
+// signCommit returns the armored detached signature of `commit` as it encodes
+// without a signature.
+func signCommit(commit *object.Commit, signKey *openpgp.Entity) (string, error) {
+	payload, err := signedPayload(commit)
+	if err != nil {
+		return "", err
+	}
+	var sig bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&sig, signKey, bytes.NewReader(payload), nil); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return sig.String(), nil
+}
+
+// VerifyCommit checks the signature of `commit` against `keys`, returning a
+// descriptive error if the commit is unsigned or the signature does not verify.
+// The signature covers the commit object as stored in `s` without its gpgsig
+// header, which keeps headers go-git does not decode, e.g. mergetag.
+func VerifyCommit(s storer.EncodedObjectStorer, commit *object.Commit, keys openpgp.EntityList) error {
+	if commit.PGPSignature == "" {
+		return errors.Errorf("commit `%s` is not signed", commit.Hash)
+	}
+	obj, err := s.EncodedObject(plumbing.CommitObject, commit.Hash)
+	if err != nil {
+		return errors.Wrapf(err, "commit `%s`", commit.Hash)
+	}
+	raw, err := readEncoded(obj)
+	if err != nil {
+		return err
+	}
+	payload := stripSignature(raw)
+	if _, err := openpgp.CheckArmoredDetachedSignature(keys, bytes.NewReader(payload), strings.NewReader(commit.PGPSignature)); err != nil {
+		return errors.Wrapf(err, "commit `%s`", commit.Hash)
+	}
+	return nil
+}
+
+// stripSignature removes the gpgsig header and its continuation lines from
+// the raw commit object `raw`.
+func stripSignature(raw []byte) []byte {
+	var b bytes.Buffer
+	inHeaders, inSignature := true, false
+	for _, line := range bytes.SplitAfter(raw, []byte("\n")) {
+		if inHeaders {
+			if len(line) == 1 && line[0] == '\n' {
+				inHeaders = false
+			} else if bytes.HasPrefix(line, []byte("gpgsig ")) {
+				inSignature = true
+				continue
+			} else if inSignature && bytes.HasPrefix(line, []byte(" ")) {
+				continue
+			} else {
+				inSignature = false
+			}
+		}
+		b.Write(line)
+	}
+	return b.Bytes()
+}
+
+// signedPayload encodes `commit` without its signature, which is the content
+// the signature covers.
+func signedPayload(commit *object.Commit) ([]byte, error) {
+	unsigned := *commit
+	unsigned.PGPSignature = ""
+	obj := &plumbing.MemoryObject{}
+	if err := unsigned.Encode(obj); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return readEncoded(obj)
+}
+
+func readEncoded(obj plumbing.EncodedObject) ([]byte, error) {
+	r, err := obj.Reader()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer r.Close()
+	content, err := ioutil.ReadAll(r)
+	return content, errors.WithStack(err)
+}
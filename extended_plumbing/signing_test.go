@@ -0,0 +1,102 @@
+package extended_plumbing
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/crypto/openpgp"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestKey(t *testing.T, name string) *openpgp.Entity {
+	key, err := openpgp.NewEntity(name, "", name+"@localhost", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// storeSigned stores the raw commit `payload` signed by `key`, with the gpgsig
+// header inserted after the committer like git does.
+func storeSigned(t *testing.T, s *memory.Storage, payload string, key *openpgp.Entity) *object.Commit {
+	var sig bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&sig, key, strings.NewReader(payload), nil); err != nil {
+		t.Fatal(err)
+	}
+	header := "gpgsig " + strings.Replace(strings.TrimSuffix(sig.String(), "\n"), "\n", "\n ", -1) + "\n"
+	c := strings.Index(payload, "\ncommitter ") + 1
+	i := c + strings.Index(payload[c:], "\n") + 1
+	raw := payload[:i] + header + payload[i:]
+	obj := s.NewEncodedObject()
+	obj.SetType(plumbing.CommitObject)
+	w, err := obj.Writer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte(raw))
+	w.Close()
+	hash, err := s.SetEncodedObject(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err := object.GetCommit(s, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return commit
+}
+
+func TestVerifyCommit(t *testing.T) {
+	s := memory.NewStorage()
+	key, other := newTestKey(t, "signer"), newTestKey(t, "other")
+	tree, err := WriteTree(s, &object.Tree{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := object.Signature{Name: "test", Email: "test@localhost", When: time.Unix(1500000000, 0)}
+	signed, err := CreateCommit(s, tree, nil, sig, sig, "m\n", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned, err := CreateCommit(s, tree, nil, sig, sig, "m\n", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Headers go-git does not decode are covered by the signature as well.
+	payload := fmt.Sprintf("tree %s\nauthor test <test@localhost> 1500000000 +0000\ncommitter test <test@localhost> 1500000000 +0000\nencoding ISO-8859-1\nmergetag object %s\n type commit\n tag v1\n\nm\n", tree, unsigned.Hash)
+	extraHeaders := storeSigned(t, s, payload, key)
+	tampered := storeSigned(t, s, payload, key)
+	tampered.PGPSignature = storeSigned(t, s, strings.Replace(payload, "tag v1", "tag v2", 1), key).PGPSignature
+
+	tests := []struct {
+		name    string
+		commit  *object.Commit
+		keys    openpgp.EntityList
+		wantErr string
+	}{
+		{name: "signed", commit: signed, keys: openpgp.EntityList{key}},
+		{name: "one of several keys", commit: signed, keys: openpgp.EntityList{other, key}},
+		{name: "extra headers", commit: extraHeaders, keys: openpgp.EntityList{key}},
+		{name: "unknown key", commit: signed, keys: openpgp.EntityList{other}, wantErr: "signature made by unknown entity"},
+		{name: "unsigned", commit: unsigned, keys: openpgp.EntityList{key}, wantErr: "is not signed"},
+		{name: "signature of other content", commit: tampered, keys: openpgp.EntityList{key}, wantErr: "invalid signature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyCommit(s, tt.commit, tt.keys)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
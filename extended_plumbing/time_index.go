@@ -0,0 +1,244 @@
+package extended_plumbing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// TimeIndex persists the first-parent history of refs as (time, commit hash)
+// records, oldest first, one file per ref. It lets ResolveCommit binary search
+// the history instead of walking it. An index is only used while its last
+// record is the ref head, otherwise ResolveCommit falls back to the walk.
+//
+// The time of a record is the earliest committer time of its commit and every
+// later commit. That keeps the records sorted when committer times decrease
+// along the history, and the last commit at or before a time is still the last
+// record at or before it.
+//
+// A file consists of an 8 byte header, the magic `timeIndexMagic` padded with
+// zeros, and 28 byte records of a big endian unix time and a hash.
+type TimeIndex struct {
+	dir string
+}
+
+const (
+	timeIndexMagic      = "GDTI\x00\x02"
+	timeIndexHeaderSize = 8
+	timeIndexRecordSize = 8 + 20
+)
+
+type timeRecord struct {
+	time int64
+	hash plumbing.Hash
+}
+
+// NewTimeIndex returns the index stored under `dir`.
+func NewTimeIndex(dir string) *TimeIndex {
+	return &TimeIndex{dir: dir}
+}
+
+func (x *TimeIndex) path(refName plumbing.ReferenceName) string {
+	return filepath.Join(x.dir, filepath.FromSlash(string(refName)))
+}
+
+// Lookup finds the first commit along the first-parent history of `reference`
+// committed at or before `time`, given the ref points at `head`. The hash is
+// zero if no commit qualifies. `ok` is false if the index is missing or stale
+// and cannot answer the lookup.
+func (x *TimeIndex) Lookup(reference string, head plumbing.Hash, time time.Time) (hash plumbing.Hash, ok bool, err error) {
+	f, err := os.Open(x.path(plumbing.ReferenceName(reference)))
+	if os.IsNotExist(err) {
+		return plumbing.ZeroHash, false, nil
+	}
+	if err != nil {
+		return plumbing.ZeroHash, false, errors.WithStack(err)
+	}
+	defer f.Close()
+	n, err := readTimeIndexHeader(f)
+	if err != nil || n == 0 {
+		return plumbing.ZeroHash, false, nil
+	}
+	last, err := readTimeRecord(f, n-1)
+	if err != nil {
+		return plumbing.ZeroHash, false, err
+	}
+	if last.hash != head {
+		return plumbing.ZeroHash, false, nil
+	}
+	var readErr error
+	i := sort.Search(n, func(i int) bool {
+		r, err := readTimeRecord(f, i)
+		if err != nil {
+			readErr = err
+			return true
+		}
+		return r.time > time.Unix()
+	})
+	if readErr != nil {
+		return plumbing.ZeroHash, false, readErr
+	}
+	if i == 0 {
+		return plumbing.ZeroHash, true, nil
+	}
+	r, err := readTimeRecord(f, i-1)
+	if err != nil {
+		return plumbing.ZeroHash, false, err
+	}
+	return r.hash, true, nil
+}
+
+// Sync brings the index of `reference` up to date with the ref. Commits added
+// on top of the indexed history are appended, any other change to the ref
+// rewrites the index from scratch.
+func (x *TimeIndex) Sync(repo *git.Repository, reference string) error {
+	refName := plumbing.ReferenceName(reference)
+	ref, err := repo.Reference(refName, true)
+	if err != nil {
+		return errors.Wrapf(err, "ref `%s`", reference)
+	}
+	p := x.path(refName)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	f, err := os.OpenFile(p, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+	var last *timeRecord
+	n, err := readTimeIndexHeader(f)
+	if err == nil && n > 0 {
+		if last, err = readTimeRecord(f, n-1); err != nil {
+			return err
+		}
+		if last.hash == ref.Hash() {
+			return nil
+		}
+	}
+
+	// Walk back from the head until the indexed history is met.
+	var records []timeRecord
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return errors.Wrapf(err, "hash `%s`", ref.Hash())
+	}
+	for {
+		records = append(records, timeRecord{time: commit.Committer.When.Unix(), hash: commit.Hash})
+		if commit.NumParents() == 0 {
+			break
+		}
+		if last != nil && commit.ParentHashes[0] == last.hash {
+			break
+		}
+		if commit, err = commit.Parent(0); err != nil {
+			return errors.Wrapf(err, "parent of `%s`", records[len(records)-1].hash)
+		}
+	}
+	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+		records[i], records[j] = records[j], records[i]
+	}
+	for i := len(records) - 2; i >= 0; i-- {
+		if records[i+1].time < records[i].time {
+			records[i].time = records[i+1].time
+		}
+	}
+
+	appending := last != nil && commit.NumParents() > 0 && commit.ParentHashes[0] == last.hash
+	if !appending {
+		return writeTimeIndex(p, records)
+	}
+	// Lower the indexed records later than the new ones before appending, so
+	// that the index only ends in the head once it is consistent.
+	earliest := records[0].time
+	i := sort.Search(n, func(i int) bool {
+		r, rerr := readTimeRecord(f, i)
+		if rerr != nil {
+			err = rerr
+			return true
+		}
+		return r.time > earliest
+	})
+	if err != nil {
+		return err
+	}
+	for ; i < n; i++ {
+		var t [8]byte
+		binary.BigEndian.PutUint64(t[:], uint64(earliest))
+		if _, err := f.WriteAt(t[:], int64(timeIndexHeaderSize+i*timeIndexRecordSize)); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	_, err = f.WriteAt(encodeTimeRecords(records), int64(timeIndexHeaderSize+n*timeIndexRecordSize))
+	return errors.WithStack(err)
+}
+
+// writeTimeIndex replaces the index at `p` with `records`.
+func writeTimeIndex(p string, records []timeRecord) error {
+	header := make([]byte, timeIndexHeaderSize)
+	copy(header, timeIndexMagic)
+	tmp, err := ioutil.TempFile(filepath.Dir(p), ".tmp-")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(append(header, encodeTimeRecords(records)...))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(tmp.Name(), p))
+}
+
+// readTimeIndexHeader returns the number of records of the index in `f`. It
+// fails if the header is invalid, e.g. of an older format, or the file ends in
+// a partial record, e.g. after a crash while appending.
+func readTimeIndexHeader(f *os.File) (int, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	header := make([]byte, timeIndexHeaderSize)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return 0, errors.Wrapf(err, "time index `%s`", f.Name())
+	}
+	if !bytes.HasPrefix(header, []byte(timeIndexMagic)) {
+		return 0, errors.Errorf("time index `%s` has an invalid header", f.Name())
+	}
+	size := info.Size() - timeIndexHeaderSize
+	if size%timeIndexRecordSize != 0 {
+		return 0, errors.Errorf("time index `%s` ends in a partial record", f.Name())
+	}
+	return int(size / timeIndexRecordSize), nil
+}
+
+func readTimeRecord(f *os.File, i int) (*timeRecord, error) {
+	b := make([]byte, timeIndexRecordSize)
+	if _, err := f.ReadAt(b, int64(timeIndexHeaderSize+i*timeIndexRecordSize)); err != nil {
+		return nil, errors.Wrapf(err, "time index `%s` record %d", f.Name(), i)
+	}
+	r := &timeRecord{time: int64(binary.BigEndian.Uint64(b))}
+	copy(r.hash[:], b[8:])
+	return r, nil
+}
+
+func encodeTimeRecords(records []timeRecord) []byte {
+	b := make([]byte, 0, len(records)*timeIndexRecordSize)
+	for _, r := range records {
+		var t [8]byte
+		binary.BigEndian.PutUint64(t[:], uint64(r.time))
+		b = append(b, t[:]...)
+		b = append(b, r.hash[:]...)
+	}
+	return b
+}
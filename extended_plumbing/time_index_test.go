@@ -0,0 +1,108 @@
+package extended_plumbing
+
+import (
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestTimeIndex(t *testing.T) {
+	repo := newMemRepo(t)
+	x := NewTimeIndex(tempDir(t))
+	base := time.Unix(1500000000, 0)
+	at := func(seconds int) time.Time { return base.Add(time.Duration(seconds) * time.Second) }
+	sync := func() {
+		if err := x.Sync(repo, testRef); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// check compares every lookup around the history with walking it.
+	check := func(head plumbing.Hash) {
+		t.Helper()
+		commit, err := repo.CommitObject(head)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for s := -1; s <= 10; s++ {
+			hash, ok, err := x.Lookup(testRef, head, at(s))
+			if err != nil || !ok {
+				t.Fatalf("time %d: got %v, %v, want an answer", s, ok, err)
+			}
+			want := plumbing.ZeroHash
+			if c, err := resolveFirstParent(repo, testRef, commit, at(s), nil); err != nil {
+				t.Fatal(err)
+			} else if c != nil {
+				want = c.Hash
+			}
+			if hash != want {
+				t.Errorf("time %d: got %s, want %s", s, hash, want)
+			}
+		}
+	}
+
+	a := commitOn(t, repo, plumbing.ZeroHash, "a", at(1))
+	b := commitOn(t, repo, a, "b", at(3))
+	if _, ok, err := x.Lookup(testRef, b, at(2)); err != nil || ok {
+		t.Fatalf("got %v, %v from a missing index", ok, err)
+	}
+	sync()
+	check(b)
+
+	// Commits on top of the indexed history are appended.
+	c := commitOn(t, repo, b, "c", at(5))
+	if _, ok, _ := x.Lookup(testRef, c, at(4)); ok {
+		t.Error("stale index answered a lookup")
+	}
+	sync()
+	check(c)
+
+	// Backdated commits keep the index usable.
+	d := commitOn(t, repo, c, "d", at(2))
+	e := commitOn(t, repo, d, "e", at(7))
+	sync()
+	check(e)
+	f := commitOn(t, repo, e, "f", at(4))
+	g := commitOn(t, repo, f, "g", at(9))
+	sync()
+	check(g)
+
+	// Rewriting the ref rewrites the index.
+	h := commitOn(t, repo, b, "h", at(6))
+	if _, ok, _ := x.Lookup(testRef, h, at(4)); ok {
+		t.Error("stale index answered a lookup after a rewrite")
+	}
+	sync()
+	check(h)
+
+	// A partial record, e.g. of a crash while appending, is rebuilt.
+	p := x.path(testRef)
+	info, err := os.Stat(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(p, info.Size()-1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := x.Lookup(testRef, h, at(4)); err != nil || ok {
+		t.Errorf("got %v, %v from a truncated index", ok, err)
+	}
+	sync()
+	check(h)
+}
+
+func TestResolveCommitIndex(t *testing.T) {
+	repo := newMemRepo(t)
+	x := NewTimeIndex(tempDir(t))
+	base := time.Unix(1500000000, 0)
+	a := commitOn(t, repo, plumbing.ZeroHash, "a", base)
+	commitOn(t, repo, a, "b", base.Add(time.Hour))
+	if err := x.Sync(repo, testRef); err != nil {
+		t.Fatal(err)
+	}
+	for _, index := range []*TimeIndex{nil, x} {
+		if c, err := ResolveCommit(repo, testRef, base.Add(time.Minute), FirstParent, index); err != nil || c.Hash != a {
+			t.Errorf("got %v, %v, want %s", c, err, a)
+		}
+	}
+}
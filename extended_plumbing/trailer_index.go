@@ -0,0 +1,184 @@
+package extended_plumbing
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TrailerIndex maps the values of one trailer key, e.g. idempotency keys, to
+// the commits carrying them along the first-parent history of refs, one file
+// per ref. Only commits within a retention window are kept, so that finding a
+// recent commit by trailer does not walk the history.
+//
+// A file starts with the hash of the commit it was synced to and a newline,
+// followed by a `<hash> <unix time> <value>` line per commit, oldest first.
+type TrailerIndex struct {
+	dir string
+	key string
+}
+
+const trailerIndexHeaderSize = 40 + 1
+
+type trailerRecord struct {
+	hash  plumbing.Hash
+	time  int64
+	value string
+}
+
+// NewTrailerIndex returns the index of trailer `key` stored under `dir`.
+func NewTrailerIndex(dir, key string) *TrailerIndex {
+	return &TrailerIndex{dir: dir, key: key}
+}
+
+func (x *TrailerIndex) path(refName plumbing.ReferenceName) string {
+	return filepath.Join(x.dir, filepath.FromSlash(string(refName)))
+}
+
+// Lookup finds the latest commit along the first-parent history of `reference`
+// committed after `since` with a trailer of the value `value`, given the ref
+// points at `head`. The hash is zero if there is none. `ok` is false if the
+// index is missing or stale and cannot answer the lookup.
+func (x *TrailerIndex) Lookup(reference string, head plumbing.Hash, value string, since time.Time) (hash plumbing.Hash, ok bool, err error) {
+	b, err := ioutil.ReadFile(x.path(plumbing.ReferenceName(reference)))
+	if os.IsNotExist(err) {
+		return plumbing.ZeroHash, false, nil
+	}
+	if err != nil {
+		return plumbing.ZeroHash, false, errors.WithStack(err)
+	}
+	synced, records, err := parseTrailerIndex(b)
+	if err != nil || synced != head {
+		return plumbing.ZeroHash, false, nil
+	}
+	for i := len(records) - 1; i >= 0 && records[i].time > since.Unix(); i-- {
+		if records[i].value == value {
+			return records[i].hash, true, nil
+		}
+	}
+	return plumbing.ZeroHash, true, nil
+}
+
+// Sync brings the index of `reference` up to date with the ref, dropping
+// commits committed before `since`. Commits added on top of the indexed
+// history are appended, any other change to the ref rewrites the index from
+// the commits after `since`.
+func (x *TrailerIndex) Sync(repo *git.Repository, reference string, since time.Time) error {
+	refName := plumbing.ReferenceName(reference)
+	ref, err := repo.Reference(refName, true)
+	if err != nil {
+		return errors.Wrapf(err, "ref `%s`", reference)
+	}
+	p := x.path(refName)
+	var synced plumbing.Hash
+	var records []trailerRecord
+	b, err := ioutil.ReadFile(p)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+	if err == nil {
+		if synced, records, err = parseTrailerIndex(b); err != nil {
+			synced, records = plumbing.ZeroHash, nil
+		}
+	}
+	if synced == ref.Hash() {
+		return nil
+	}
+
+	// Walk back from the head until the indexed history or the end of the
+	// window is met.
+	var added []trailerRecord
+	met := false
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return errors.Wrapf(err, "hash `%s`", ref.Hash())
+	}
+	for commit.Committer.When.After(since) {
+		if commit.Hash == synced {
+			met = true
+			break
+		}
+		trailers, _ := trailerBlock(commit.Message)
+		for _, t := range trailers {
+			if t.Key == x.key {
+				added = append(added, trailerRecord{hash: commit.Hash, time: commit.Committer.When.Unix(), value: t.Value})
+			}
+		}
+		if commit.NumParents() == 0 {
+			break
+		}
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return errors.Wrapf(err, "parent of `%s`", commit.Hash)
+		}
+		commit = parent
+	}
+	if !met {
+		records = nil
+	}
+	for i := len(added) - 1; i >= 0; i-- {
+		records = append(records, added[i])
+	}
+
+	// Drop the commits that fell out of the window.
+	i := 0
+	for i < len(records) && records[i].time <= since.Unix() {
+		i++
+	}
+	return writeTrailerIndex(p, ref.Hash(), records[i:])
+}
+
+func parseTrailerIndex(b []byte) (plumbing.Hash, []trailerRecord, error) {
+	if len(b) < trailerIndexHeaderSize || b[trailerIndexHeaderSize-1] != '\n' {
+		return plumbing.ZeroHash, nil, errors.Errorf("trailer index has an invalid header")
+	}
+	synced := plumbing.NewHash(string(b[:trailerIndexHeaderSize-1]))
+	var records []trailerRecord
+	scanner := bufio.NewScanner(bytes.NewReader(b[trailerIndexHeaderSize:]))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), " ", 3)
+		if len(fields) != 3 {
+			return plumbing.ZeroHash, nil, errors.Errorf("trailer index has an invalid line `%s`", scanner.Text())
+		}
+		t, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return plumbing.ZeroHash, nil, errors.Errorf("trailer index has an invalid line `%s`", scanner.Text())
+		}
+		records = append(records, trailerRecord{hash: plumbing.NewHash(fields[0]), time: t, value: fields[2]})
+	}
+	return synced, records, errors.WithStack(scanner.Err())
+}
+
+// writeTrailerIndex replaces the index at `p`.
+func writeTrailerIndex(p string, synced plumbing.Hash, records []trailerRecord) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s\n", synced)
+	for _, r := range records {
+		fmt.Fprintf(&b, "%s %d %s\n", r.hash, r.time, r.value)
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(p), ".tmp-")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(b.Bytes())
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.Rename(tmp.Name(), p))
+}
@@ -0,0 +1,114 @@
+package extended_plumbing
+
+import (
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+const testRef = "refs/heads/master"
+
+// tempDir returns a directory removed at the end of the test.
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "gitdb-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func newMemRepo(t *testing.T) *git.Repository {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+// commitOn creates a commit with an empty tree on top of `parent`, or a root
+// commit if `parent` is zero, and points testRef at it.
+func commitOn(t *testing.T, repo *git.Repository, parent plumbing.Hash, msg string, when time.Time) plumbing.Hash {
+	tree, err := WriteTree(repo.Storer, &object.Tree{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parents []plumbing.Hash
+	if !parent.IsZero() {
+		parents = []plumbing.Hash{parent}
+	}
+	sig := object.Signature{Name: "test", Email: "test@localhost", When: when}
+	commit, err := CreateCommit(repo.Storer, tree, parents, sig, sig, msg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(testRef, commit.Hash)); err != nil {
+		t.Fatal(err)
+	}
+	return commit.Hash
+}
+
+func TestTrailerIndex(t *testing.T) {
+	repo := newMemRepo(t)
+	x := NewTrailerIndex(tempDir(t), "Key")
+	now := time.Now()
+	since := now.Add(-time.Hour)
+	lookup := func(head plumbing.Hash, value string) (plumbing.Hash, bool) {
+		hash, ok, err := x.Lookup(testRef, head, value, since)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash, ok
+	}
+
+	old := commitOn(t, repo, plumbing.ZeroHash, "old\n\nKey: a\n", now.Add(-2*time.Hour))
+	a := commitOn(t, repo, old, "a\n\nKey: a\n", now.Add(-time.Minute))
+	if _, ok := lookup(a, "a"); ok {
+		t.Fatal("missing index answered a lookup")
+	}
+	if err := x.Sync(repo, testRef, since); err != nil {
+		t.Fatal(err)
+	}
+	if hash, ok := lookup(a, "a"); !ok || hash != a {
+		t.Errorf("got %s, %v, want %s", hash, ok, a)
+	}
+
+	// Commits on top of the indexed history are added.
+	b := commitOn(t, repo, a, "b\n\nOther: b\nKey: b\n", now)
+	if _, ok := lookup(b, "b"); ok {
+		t.Error("stale index answered a lookup")
+	}
+	if err := x.Sync(repo, testRef, since); err != nil {
+		t.Fatal(err)
+	}
+	for value, want := range map[string]plumbing.Hash{"a": a, "b": b, "Other": plumbing.ZeroHash, "c": plumbing.ZeroHash} {
+		if hash, ok := lookup(b, value); !ok || hash != want {
+			t.Errorf("value `%s`: got %s, %v, want %s", value, hash, ok, want)
+		}
+	}
+
+	// Rewriting the ref drops the commits no longer in its history.
+	c := commitOn(t, repo, old, "c\n\nKey: c\n", now)
+	if err := x.Sync(repo, testRef, since); err != nil {
+		t.Fatal(err)
+	}
+	for value, want := range map[string]plumbing.Hash{"a": plumbing.ZeroHash, "b": plumbing.ZeroHash, "c": c} {
+		if hash, ok := lookup(c, value); !ok || hash != want {
+			t.Errorf("value `%s`: got %s, %v, want %s", value, hash, ok, want)
+		}
+	}
+
+	// Commits leaving the window are dropped on the next sync.
+	d := commitOn(t, repo, c, "d\n", now)
+	if err := x.Sync(repo, testRef, now.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if hash, ok, err := x.Lookup(testRef, d, "c", since); err != nil || !ok || !hash.IsZero() {
+		t.Errorf("got %s, %v, %v, want no commit", hash, ok, err)
+	}
+}
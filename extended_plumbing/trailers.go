@@ -0,0 +1,102 @@
+package extended_plumbing
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	trailerKeyRegexp  = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+	trailerLineRegexp = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*):\s*(.*)$`)
+)
+
+// Trailer is a `Key: value` line in the trailer block of a commit message, the
+// last paragraph of a message with at least two paragraphs.
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// ValidateTrailer checks that `t` can be written as a single trailer line.
+func ValidateTrailer(t Trailer) error {
+	if !trailerKeyRegexp.MatchString(t.Key) {
+		return errors.Errorf("invalid trailer key `%s`", t.Key)
+	}
+	if strings.ContainsAny(t.Value, "\r\n") {
+		return errors.Errorf("trailer `%s` has a multi-line value", t.Key)
+	}
+	return nil
+}
+
+// AppendTrailers adds `trailers` to the trailer block of `msg`, starting a new
+// block if `msg` does not end with one.
+func AppendTrailers(msg string, trailers []Trailer) string {
+	if len(trailers) == 0 {
+		return msg
+	}
+	msg = strings.TrimRight(msg, "\n")
+	var b strings.Builder
+	b.WriteString(msg)
+	if _, ok := trailerBlock(msg); ok {
+		b.WriteString("\n")
+	} else {
+		b.WriteString("\n\n")
+	}
+	for _, t := range trailers {
+		fmt.Fprintf(&b, "%s: %s\n", t.Key, t.Value)
+	}
+	return b.String()
+}
+
+// ParseTrailers returns the trailers of `msg` as a map. Values of repeated keys
+// are joined with ", ".
+func ParseTrailers(msg string) map[string]string {
+	trailers, ok := trailerBlock(msg)
+	if !ok {
+		return nil
+	}
+	parsed := map[string]string{}
+	for _, t := range trailers {
+		if v, ok := parsed[t.Key]; ok {
+			parsed[t.Key] = v + ", " + t.Value
+		} else {
+			parsed[t.Key] = t.Value
+		}
+	}
+	return parsed
+}
+
+// HasTrailer tells whether the trailer block of `msg` contains `t`.
+func HasTrailer(msg string, t Trailer) bool {
+	trailers, _ := trailerBlock(msg)
+	for _, trailer := range trailers {
+		if trailer == t {
+			return true
+		}
+	}
+	return false
+}
+
+// trailerBlock parses the last paragraph of `msg` if every line in it is a
+// trailer or the continuation of one.
+func trailerBlock(msg string) ([]Trailer, bool) {
+	paragraphs := strings.Split(strings.Trim(msg, "\n"), "\n\n")
+	if len(paragraphs) < 2 {
+		return nil, false
+	}
+	var trailers []Trailer
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(trailers) > 0 {
+			trailers[len(trailers)-1].Value += " " + strings.TrimSpace(line)
+			continue
+		}
+		m := trailerLineRegexp.FindStringSubmatch(line)
+		if m == nil {
+			return nil, false
+		}
+		trailers = append(trailers, Trailer{Key: m[1], Value: strings.TrimSpace(m[2])})
+	}
+	return trailers, true
+}
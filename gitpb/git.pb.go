@@ -27,6 +27,35 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type FileMode int32
+
+const (
+	FileMode_REGULAR    FileMode = 0
+	FileMode_EXECUTABLE FileMode = 1
+	FileMode_SYMLINK    FileMode = 2
+	FileMode_GITLINK    FileMode = 3
+)
+
+var FileMode_name = map[int32]string{
+	0: "REGULAR",
+	1: "EXECUTABLE",
+	2: "SYMLINK",
+	3: "GITLINK",
+}
+var FileMode_value = map[string]int32{
+	"REGULAR":    0,
+	"EXECUTABLE": 1,
+	"SYMLINK":    2,
+	"GITLINK":    3,
+}
+
+func (x FileMode) String() string {
+	return proto.EnumName(FileMode_name, int32(x))
+}
+func (FileMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{0}
+}
+
 type ObjectType int32
 
 const (
@@ -53,7 +82,92 @@ func (x ObjectType) String() string {
 	return proto.EnumName(ObjectType_name, int32(x))
 }
 func (ObjectType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_git_53dae829a503651c, []int{0}
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{1}
+}
+
+type Traversal int32
+
+const (
+	// the first commit at or before the time along the first-parent chain
+	Traversal_FIRST_PARENT Traversal = 0
+	// the latest commit at or before the time reachable from the ref,
+	// including commits brought in by merges
+	Traversal_FULL_HISTORY Traversal = 1
+)
+
+var Traversal_name = map[int32]string{
+	0: "FIRST_PARENT",
+	1: "FULL_HISTORY",
+}
+var Traversal_value = map[string]int32{
+	"FIRST_PARENT": 0,
+	"FULL_HISTORY": 1,
+}
+
+func (x Traversal) String() string {
+	return proto.EnumName(Traversal_name, int32(x))
+}
+func (Traversal) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{2}
+}
+
+// Merge patches and JSON patches apply to JSON files, and to YAML files ending
+// in `.yaml` or `.yml` through their JSON form. The patched file is re-encoded
+// with sorted keys, keeping only the indentation of JSON files and their
+// trailing newline. YAML comments are lost.
+type PatchType int32
+
+const (
+	// RFC 7386
+	PatchType_MERGE_PATCH PatchType = 0
+	// RFC 6902
+	PatchType_JSON_PATCH PatchType = 1
+	// applied at the exact lines of its hunks
+	PatchType_UNIFIED_DIFF PatchType = 2
+)
+
+var PatchType_name = map[int32]string{
+	0: "MERGE_PATCH",
+	1: "JSON_PATCH",
+	2: "UNIFIED_DIFF",
+}
+var PatchType_value = map[string]int32{
+	"MERGE_PATCH":  0,
+	"JSON_PATCH":   1,
+	"UNIFIED_DIFF": 2,
+}
+
+func (x PatchType) String() string {
+	return proto.EnumName(PatchType_name, int32(x))
+}
+func (PatchType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{3}
+}
+
+type ChangeType int32
+
+const (
+	ChangeType_ADDED    ChangeType = 0
+	ChangeType_MODIFIED ChangeType = 1
+	ChangeType_DELETED  ChangeType = 2
+)
+
+var ChangeType_name = map[int32]string{
+	0: "ADDED",
+	1: "MODIFIED",
+	2: "DELETED",
+}
+var ChangeType_value = map[string]int32{
+	"ADDED":    0,
+	"MODIFIED": 1,
+	"DELETED":  2,
+}
+
+func (x ChangeType) String() string {
+	return proto.EnumName(ChangeType_name, int32(x))
+}
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{4}
 }
 
 type Object struct {
@@ -71,7 +185,7 @@ func (m *Object) Reset()         { *m = Object{} }
 func (m *Object) String() string { return proto.CompactTextString(m) }
 func (*Object) ProtoMessage()    {}
 func (*Object) Descriptor() ([]byte, []int) {
-	return fileDescriptor_git_53dae829a503651c, []int{0}
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{0}
 }
 func (m *Object) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -242,8 +356,12 @@ func _Object_OneofSizer(msg proto.Message) (n int) {
 }
 
 type Blob struct {
-	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	Content              []byte   `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Hash    string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// set when the blob is looked up by path; a gitlink has the submodule
+	// commit as hash and no content
+	Mode                 FileMode `protobuf:"varint,3,opt,name=mode,proto3,enum=gitpb.FileMode" json:"mode,omitempty"`
+	SymlinkTarget        string   `protobuf:"bytes,4,opt,name=symlinkTarget,proto3" json:"symlinkTarget,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -253,7 +371,7 @@ func (m *Blob) Reset()         { *m = Blob{} }
 func (m *Blob) String() string { return proto.CompactTextString(m) }
 func (*Blob) ProtoMessage()    {}
 func (*Blob) Descriptor() ([]byte, []int) {
-	return fileDescriptor_git_53dae829a503651c, []int{1}
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{1}
 }
 func (m *Blob) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -296,6 +414,20 @@ func (m *Blob) GetContent() []byte {
 	return nil
 }
 
+func (m *Blob) GetMode() FileMode {
+	if m != nil {
+		return m.Mode
+	}
+	return FileMode_REGULAR
+}
+
+func (m *Blob) GetSymlinkTarget() string {
+	if m != nil {
+		return m.SymlinkTarget
+	}
+	return ""
+}
+
 type TreeEntry struct {
 	// git spec
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -303,6 +435,7 @@ type TreeEntry struct {
 	Mode uint32 `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
 	// sugar
 	Entry                *Object  `protobuf:"bytes,4,opt,name=entry" json:"entry,omitempty"`
+	SymlinkTarget        string   `protobuf:"bytes,5,opt,name=symlinkTarget,proto3" json:"symlinkTarget,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -312,7 +445,7 @@ func (m *TreeEntry) Reset()         { *m = TreeEntry{} }
 func (m *TreeEntry) String() string { return proto.CompactTextString(m) }
 func (*TreeEntry) ProtoMessage()    {}
 func (*TreeEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_git_53dae829a503651c, []int{2}
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{2}
 }
 func (m *TreeEntry) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -369,6 +502,13 @@ func (m *TreeEntry) GetEntry() *Object {
 	return nil
 }
 
+func (m *TreeEntry) GetSymlinkTarget() string {
+	if m != nil {
+		return m.SymlinkTarget
+	}
+	return ""
+}
+
 type Tree struct {
 	Hash                 string       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Entries              []*TreeEntry `protobuf:"bytes,2,rep,name=entries" json:"entries,omitempty"`
@@ -381,7 +521,7 @@ func (m *Tree) Reset()         { *m = Tree{} }
 func (m *Tree) String() string { return proto.CompactTextString(m) }
 func (*Tree) ProtoMessage()    {}
 func (*Tree) Descriptor() ([]byte, []int) {
-	return fileDescriptor_git_53dae829a503651c, []int{3}
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{3}
 }
 func (m *Tree) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -437,7 +577,7 @@ func (m *Signature) Reset()         { *m = Signature{} }
 func (m *Signature) String() string { return proto.CompactTextString(m) }
 func (*Signature) ProtoMessage()    {}
 func (*Signature) Descriptor() ([]byte, []int) {
-	return fileDescriptor_git_53dae829a503651c, []int{4}
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{4}
 }
 func (m *Signature) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -496,18 +636,23 @@ type Commit struct {
 	Tree      string     `protobuf:"bytes,5,opt,name=tree,proto3" json:"tree,omitempty"`
 	Parents   []string   `protobuf:"bytes,6,rep,name=parents" json:"parents,omitempty"`
 	// sugar
-	TreeObject           *Object   `protobuf:"bytes,7,opt,name=treeObject" json:"treeObject,omitempty"`
-	ParentObjects        []*Object `protobuf:"bytes,8,rep,name=parentObjects" json:"parentObjects,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	TreeObject    *Object   `protobuf:"bytes,7,opt,name=treeObject" json:"treeObject,omitempty"`
+	ParentObjects []*Object `protobuf:"bytes,8,rep,name=parentObjects" json:"parentObjects,omitempty"`
+	// parsed from the trailer block of the message, values of repeated keys
+	// are joined with ", "
+	Trailers map[string]string `protobuf:"bytes,9,rep,name=trailers" json:"trailers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// armored OpenPGP signature, empty if the commit is unsigned
+	Signature            string   `protobuf:"bytes,10,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Commit) Reset()         { *m = Commit{} }
 func (m *Commit) String() string { return proto.CompactTextString(m) }
 func (*Commit) ProtoMessage()    {}
 func (*Commit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_git_53dae829a503651c, []int{5}
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{5}
 }
 func (m *Commit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -592,22 +737,45 @@ func (m *Commit) GetParentObjects() []*Object {
 	return nil
 }
 
+func (m *Commit) GetTrailers() map[string]string {
+	if m != nil {
+		return m.Trailers
+	}
+	return nil
+}
+
+func (m *Commit) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
 type ObjectIdentifier struct {
-	Repo                 string     `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
-	Type                 ObjectType `protobuf:"varint,2,opt,name=type,proto3,enum=gitpb.ObjectType" json:"type,omitempty"`
-	Ref                  string     `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
-	Path                 string     `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
-	Time                 int64      `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Repo string     `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	Type ObjectType `protobuf:"varint,2,opt,name=type,proto3,enum=gitpb.ObjectType" json:"type,omitempty"`
+	Ref  string     `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
+	Path string     `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	Time int64      `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`
+	// how the commit at `time` is looked up in the history of `ref`, also
+	// applies to `<ref>@{<time>}` revisions
+	Traversal Traversal `protobuf:"varint,6,opt,name=traversal,proto3,enum=gitpb.Traversal" json:"traversal,omitempty"`
+	// identifies the object instead of `ref` and `time`. Accepts full hashes,
+	// commit hashes shortened to at least 7 characters, refs and tags,
+	// `<ref>@{<RFC 3339 time>}`, each optionally followed by `~N` or `^N`.
+	// Hashes of trees and blobs name the object itself, `path` then has to be
+	// empty for blobs and is relative to the tree for trees.
+	Revision             string   `protobuf:"bytes,7,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ObjectIdentifier) Reset()         { *m = ObjectIdentifier{} }
 func (m *ObjectIdentifier) String() string { return proto.CompactTextString(m) }
 func (*ObjectIdentifier) ProtoMessage()    {}
 func (*ObjectIdentifier) Descriptor() ([]byte, []int) {
-	return fileDescriptor_git_53dae829a503651c, []int{6}
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{6}
 }
 func (m *ObjectIdentifier) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -671,18 +839,37 @@ func (m *ObjectIdentifier) GetTime() int64 {
 	return 0
 }
 
+func (m *ObjectIdentifier) GetTraversal() Traversal {
+	if m != nil {
+		return m.Traversal
+	}
+	return Traversal_FIRST_PARENT
+}
+
+func (m *ObjectIdentifier) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
 type GetObjectRequest struct {
-	Id                   *ObjectIdentifier `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Id *ObjectIdentifier `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// check the signatures of the resolved commit and all its ancestors
+	// against the configured signing key and trusted keys
+	Verify bool `protobuf:"varint,2,opt,name=verify,proto3" json:"verify,omitempty"`
+	// sugar fields to fill in, none if unset
+	Expand               *Expansion `protobuf:"bytes,3,opt,name=expand" json:"expand,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *GetObjectRequest) Reset()         { *m = GetObjectRequest{} }
 func (m *GetObjectRequest) String() string { return proto.CompactTextString(m) }
 func (*GetObjectRequest) ProtoMessage()    {}
 func (*GetObjectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_git_53dae829a503651c, []int{7}
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{7}
 }
 func (m *GetObjectRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -718,8 +905,106 @@ func (m *GetObjectRequest) GetId() *ObjectIdentifier {
 	return nil
 }
 
+func (m *GetObjectRequest) GetVerify() bool {
+	if m != nil {
+		return m.Verify
+	}
+	return false
+}
+
+func (m *GetObjectRequest) GetExpand() *Expansion {
+	if m != nil {
+		return m.Expand
+	}
+	return nil
+}
+
+// Expansion controls how much of the object graph around the requested object
+// is inlined into the response, limited by the maximum gRPC message size.
+type Expansion struct {
+	// levels of tree entries whose `entry` is filled in, i.e. 1 inlines the
+	// subtrees of the requested tree or the root tree of the requested commit,
+	// 2 also the subtrees of those. Negative for unlimited depth.
+	TreeDepth int32 `protobuf:"varint,1,opt,name=treeDepth,proto3" json:"treeDepth,omitempty"`
+	// also inline blobs of entries within `treeDepth`
+	Blobs bool `protobuf:"varint,2,opt,name=blobs,proto3" json:"blobs,omitempty"`
+	// blobs larger than this are not inlined, 0 for no limit
+	MaxBlobBytes int64 `protobuf:"varint,3,opt,name=maxBlobBytes,proto3" json:"maxBlobBytes,omitempty"`
+	// generations of ancestors inlined into `parentObjects` of commits
+	Parents              int32    `protobuf:"varint,4,opt,name=parents,proto3" json:"parents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Expansion) Reset()         { *m = Expansion{} }
+func (m *Expansion) String() string { return proto.CompactTextString(m) }
+func (*Expansion) ProtoMessage()    {}
+func (*Expansion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{8}
+}
+func (m *Expansion) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Expansion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Expansion.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Expansion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Expansion.Merge(dst, src)
+}
+func (m *Expansion) XXX_Size() int {
+	return m.Size()
+}
+func (m *Expansion) XXX_DiscardUnknown() {
+	xxx_messageInfo_Expansion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Expansion proto.InternalMessageInfo
+
+func (m *Expansion) GetTreeDepth() int32 {
+	if m != nil {
+		return m.TreeDepth
+	}
+	return 0
+}
+
+func (m *Expansion) GetBlobs() bool {
+	if m != nil {
+		return m.Blobs
+	}
+	return false
+}
+
+func (m *Expansion) GetMaxBlobBytes() int64 {
+	if m != nil {
+		return m.MaxBlobBytes
+	}
+	return 0
+}
+
+func (m *Expansion) GetParents() int32 {
+	if m != nil {
+		return m.Parents
+	}
+	return 0
+}
+
 type GetObjectResponse struct {
-	Object               *Object  `protobuf:"bytes,1,opt,name=object" json:"object,omitempty"`
+	Object *Object `protobuf:"bytes,1,opt,name=object" json:"object,omitempty"`
+	// set if the request asked to verify signatures
+	Verification *Verification `protobuf:"bytes,2,opt,name=verification" json:"verification,omitempty"`
+	// set if expansions were left out to keep the response within the size
+	// limit; the entries and parents concerned are not filled in
+	Truncated            bool     `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -729,7 +1014,7 @@ func (m *GetObjectResponse) Reset()         { *m = GetObjectResponse{} }
 func (m *GetObjectResponse) String() string { return proto.CompactTextString(m) }
 func (*GetObjectResponse) ProtoMessage()    {}
 func (*GetObjectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_git_53dae829a503651c, []int{8}
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{9}
 }
 func (m *GetObjectResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -765,29 +1050,40 @@ func (m *GetObjectResponse) GetObject() *Object {
 	return nil
 }
 
-type WriteCommitRequest struct {
-	Repo                 string            `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
-	Ref                  string            `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
-	Upserts              map[string][]byte `protobuf:"bytes,3,rep,name=upserts" json:"upserts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Deletes              []string          `protobuf:"bytes,4,rep,name=deletes" json:"deletes,omitempty"`
-	Msg                  string            `protobuf:"bytes,5,opt,name=msg,proto3" json:"msg,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *GetObjectResponse) GetVerification() *Verification {
+	if m != nil {
+		return m.Verification
+	}
+	return nil
 }
 
-func (m *WriteCommitRequest) Reset()         { *m = WriteCommitRequest{} }
-func (m *WriteCommitRequest) String() string { return proto.CompactTextString(m) }
-func (*WriteCommitRequest) ProtoMessage()    {}
-func (*WriteCommitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_git_53dae829a503651c, []int{9}
+func (m *GetObjectResponse) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
 }
-func (m *WriteCommitRequest) XXX_Unmarshal(b []byte) error {
+
+type VerificationFailure struct {
+	Commit               string   `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerificationFailure) Reset()         { *m = VerificationFailure{} }
+func (m *VerificationFailure) String() string { return proto.CompactTextString(m) }
+func (*VerificationFailure) ProtoMessage()    {}
+func (*VerificationFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{10}
+}
+func (m *VerificationFailure) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *WriteCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *VerificationFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_WriteCommitRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_VerificationFailure.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -797,72 +1093,130 @@ func (m *WriteCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *WriteCommitRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WriteCommitRequest.Merge(dst, src)
+func (dst *VerificationFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerificationFailure.Merge(dst, src)
 }
-func (m *WriteCommitRequest) XXX_Size() int {
+func (m *VerificationFailure) XXX_Size() int {
 	return m.Size()
 }
-func (m *WriteCommitRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_WriteCommitRequest.DiscardUnknown(m)
+func (m *VerificationFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerificationFailure.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_WriteCommitRequest proto.InternalMessageInfo
+var xxx_messageInfo_VerificationFailure proto.InternalMessageInfo
 
-func (m *WriteCommitRequest) GetRepo() string {
+func (m *VerificationFailure) GetCommit() string {
 	if m != nil {
-		return m.Repo
+		return m.Commit
 	}
 	return ""
 }
 
-func (m *WriteCommitRequest) GetRef() string {
+func (m *VerificationFailure) GetReason() string {
 	if m != nil {
-		return m.Ref
+		return m.Reason
 	}
 	return ""
 }
 
-func (m *WriteCommitRequest) GetUpserts() map[string][]byte {
+type Verification struct {
+	// true if every commit in the resolved history is validly signed
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// number of commits checked, histories verified by earlier requests are
+	// not checked again
+	Checked  int64                  `protobuf:"varint,2,opt,name=checked,proto3" json:"checked,omitempty"`
+	Failures []*VerificationFailure `protobuf:"bytes,3,rep,name=failures" json:"failures,omitempty"`
+	// set if the history is too long to check completely, valid is false then
+	Truncated            bool     `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Verification) Reset()         { *m = Verification{} }
+func (m *Verification) String() string { return proto.CompactTextString(m) }
+func (*Verification) ProtoMessage()    {}
+func (*Verification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{11}
+}
+func (m *Verification) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Verification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Verification.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Verification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Verification.Merge(dst, src)
+}
+func (m *Verification) XXX_Size() int {
+	return m.Size()
+}
+func (m *Verification) XXX_DiscardUnknown() {
+	xxx_messageInfo_Verification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Verification proto.InternalMessageInfo
+
+func (m *Verification) GetValid() bool {
 	if m != nil {
-		return m.Upserts
+		return m.Valid
 	}
-	return nil
+	return false
 }
 
-func (m *WriteCommitRequest) GetDeletes() []string {
+func (m *Verification) GetChecked() int64 {
 	if m != nil {
-		return m.Deletes
+		return m.Checked
+	}
+	return 0
+}
+
+func (m *Verification) GetFailures() []*VerificationFailure {
+	if m != nil {
+		return m.Failures
 	}
 	return nil
 }
 
-func (m *WriteCommitRequest) GetMsg() string {
+func (m *Verification) GetTruncated() bool {
 	if m != nil {
-		return m.Msg
+		return m.Truncated
 	}
-	return ""
+	return false
 }
 
-type WriteCommitResponse struct {
-	Commit               *Commit  `protobuf:"bytes,1,opt,name=commit" json:"commit,omitempty"`
+type Precondition struct {
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// blob hash the path must currently have
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// the path must not currently exist
+	Absent               bool     `protobuf:"varint,3,opt,name=absent,proto3" json:"absent,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *WriteCommitResponse) Reset()         { *m = WriteCommitResponse{} }
-func (m *WriteCommitResponse) String() string { return proto.CompactTextString(m) }
-func (*WriteCommitResponse) ProtoMessage()    {}
-func (*WriteCommitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_git_53dae829a503651c, []int{10}
+func (m *Precondition) Reset()         { *m = Precondition{} }
+func (m *Precondition) String() string { return proto.CompactTextString(m) }
+func (*Precondition) ProtoMessage()    {}
+func (*Precondition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{12}
 }
-func (m *WriteCommitResponse) XXX_Unmarshal(b []byte) error {
+func (m *Precondition) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *WriteCommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Precondition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_WriteCommitResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Precondition.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -872,1056 +1226,8518 @@ func (m *WriteCommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *WriteCommitResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WriteCommitResponse.Merge(dst, src)
+func (dst *Precondition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Precondition.Merge(dst, src)
 }
-func (m *WriteCommitResponse) XXX_Size() int {
+func (m *Precondition) XXX_Size() int {
 	return m.Size()
 }
-func (m *WriteCommitResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_WriteCommitResponse.DiscardUnknown(m)
+func (m *Precondition) XXX_DiscardUnknown() {
+	xxx_messageInfo_Precondition.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_WriteCommitResponse proto.InternalMessageInfo
+var xxx_messageInfo_Precondition proto.InternalMessageInfo
 
-func (m *WriteCommitResponse) GetCommit() *Commit {
+func (m *Precondition) GetPath() string {
 	if m != nil {
-		return m.Commit
+		return m.Path
 	}
-	return nil
+	return ""
 }
 
-func init() {
-	proto.RegisterType((*Object)(nil), "gitpb.Object")
-	proto.RegisterType((*Blob)(nil), "gitpb.Blob")
-	proto.RegisterType((*TreeEntry)(nil), "gitpb.TreeEntry")
-	proto.RegisterType((*Tree)(nil), "gitpb.Tree")
-	proto.RegisterType((*Signature)(nil), "gitpb.Signature")
-	proto.RegisterType((*Commit)(nil), "gitpb.Commit")
-	proto.RegisterType((*ObjectIdentifier)(nil), "gitpb.ObjectIdentifier")
-	proto.RegisterType((*GetObjectRequest)(nil), "gitpb.GetObjectRequest")
-	proto.RegisterType((*GetObjectResponse)(nil), "gitpb.GetObjectResponse")
-	proto.RegisterType((*WriteCommitRequest)(nil), "gitpb.WriteCommitRequest")
-	proto.RegisterMapType((map[string][]byte)(nil), "gitpb.WriteCommitRequest.UpsertsEntry")
-	proto.RegisterType((*WriteCommitResponse)(nil), "gitpb.WriteCommitResponse")
-	proto.RegisterEnum("gitpb.ObjectType", ObjectType_name, ObjectType_value)
+func (m *Precondition) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *Precondition) GetAbsent() bool {
+	if m != nil {
+		return m.Absent
+	}
+	return false
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// GitClient is the client API for Git service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type GitClient interface {
-	Health(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
-	GetObject(ctx context.Context, in *GetObjectRequest, opts ...grpc.CallOption) (*GetObjectResponse, error)
-	WriteCommit(ctx context.Context, in *WriteCommitRequest, opts ...grpc.CallOption) (*WriteCommitResponse, error)
+type Upsert struct {
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// the link target for a symlink, empty for a gitlink
+	Content []byte   `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Mode    FileMode `protobuf:"varint,3,opt,name=mode,proto3,enum=gitpb.FileMode" json:"mode,omitempty"`
+	// submodule commit of a gitlink
+	Gitlink              string   `protobuf:"bytes,4,opt,name=gitlink,proto3" json:"gitlink,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type gitClient struct {
-	cc *grpc.ClientConn
+func (m *Upsert) Reset()         { *m = Upsert{} }
+func (m *Upsert) String() string { return proto.CompactTextString(m) }
+func (*Upsert) ProtoMessage()    {}
+func (*Upsert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{13}
 }
-
-func NewGitClient(cc *grpc.ClientConn) GitClient {
-	return &gitClient{cc}
+func (m *Upsert) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Upsert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Upsert.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Upsert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Upsert.Merge(dst, src)
+}
+func (m *Upsert) XXX_Size() int {
+	return m.Size()
+}
+func (m *Upsert) XXX_DiscardUnknown() {
+	xxx_messageInfo_Upsert.DiscardUnknown(m)
 }
 
-func (c *gitClient) Health(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/gitpb.Git/Health", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Upsert proto.InternalMessageInfo
+
+func (m *Upsert) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	return out, nil
+	return ""
 }
 
-func (c *gitClient) GetObject(ctx context.Context, in *GetObjectRequest, opts ...grpc.CallOption) (*GetObjectResponse, error) {
-	out := new(GetObjectResponse)
-	err := c.cc.Invoke(ctx, "/gitpb.Git/GetObject", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Upsert) GetContent() []byte {
+	if m != nil {
+		return m.Content
 	}
-	return out, nil
+	return nil
 }
 
-func (c *gitClient) WriteCommit(ctx context.Context, in *WriteCommitRequest, opts ...grpc.CallOption) (*WriteCommitResponse, error) {
-	out := new(WriteCommitResponse)
-	err := c.cc.Invoke(ctx, "/gitpb.Git/WriteCommit", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Upsert) GetMode() FileMode {
+	if m != nil {
+		return m.Mode
 	}
-	return out, nil
+	return FileMode_REGULAR
 }
 
-// GitServer is the server API for Git service.
-type GitServer interface {
-	Health(context.Context, *empty.Empty) (*empty.Empty, error)
-	GetObject(context.Context, *GetObjectRequest) (*GetObjectResponse, error)
-	WriteCommit(context.Context, *WriteCommitRequest) (*WriteCommitResponse, error)
+func (m *Upsert) GetGitlink() string {
+	if m != nil {
+		return m.Gitlink
+	}
+	return ""
 }
 
-func RegisterGitServer(s *grpc.Server, srv GitServer) {
-	s.RegisterService(&_Git_serviceDesc, srv)
+type Patch struct {
+	Path                 string    `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Type                 PatchType `protobuf:"varint,2,opt,name=type,proto3,enum=gitpb.PatchType" json:"type,omitempty"`
+	Patch                []byte    `protobuf:"bytes,3,opt,name=patch,proto3" json:"patch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func _Git_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(GitServer).Health(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/gitpb.Git/Health",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GitServer).Health(ctx, req.(*empty.Empty))
+func (m *Patch) Reset()         { *m = Patch{} }
+func (m *Patch) String() string { return proto.CompactTextString(m) }
+func (*Patch) ProtoMessage()    {}
+func (*Patch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{14}
+}
+func (m *Patch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Patch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Patch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (dst *Patch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Patch.Merge(dst, src)
+}
+func (m *Patch) XXX_Size() int {
+	return m.Size()
+}
+func (m *Patch) XXX_DiscardUnknown() {
+	xxx_messageInfo_Patch.DiscardUnknown(m)
 }
 
-func _Git_GetObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetObjectRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(GitServer).GetObject(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/gitpb.Git/GetObject",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GitServer).GetObject(ctx, req.(*GetObjectRequest))
+var xxx_messageInfo_Patch proto.InternalMessageInfo
+
+func (m *Patch) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Git_WriteCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WriteCommitRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(GitServer).WriteCommit(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/gitpb.Git/WriteCommit",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GitServer).WriteCommit(ctx, req.(*WriteCommitRequest))
+func (m *Patch) GetType() PatchType {
+	if m != nil {
+		return m.Type
 	}
-	return interceptor(ctx, in, info, handler)
+	return PatchType_MERGE_PATCH
 }
 
-var _Git_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "gitpb.Git",
-	HandlerType: (*GitServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Health",
-			Handler:    _Git_Health_Handler,
-		},
-		{
-			MethodName: "GetObject",
-			Handler:    _Git_GetObject_Handler,
-		},
-		{
-			MethodName: "WriteCommit",
-			Handler:    _Git_WriteCommit_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "git.proto",
+func (m *Patch) GetPatch() []byte {
+	if m != nil {
+		return m.Patch
+	}
+	return nil
 }
 
-func (m *Object) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type Move struct {
+	// file or directory to move, reusing its blob or tree as is
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// must not exist yet
+	To                   string   `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Object) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Obj != nil {
-		nn1, err := m.Obj.MarshalTo(dAtA[i:])
+func (m *Move) Reset()         { *m = Move{} }
+func (m *Move) String() string { return proto.CompactTextString(m) }
+func (*Move) ProtoMessage()    {}
+func (*Move) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{15}
+}
+func (m *Move) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Move) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Move.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += nn1
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+}
+func (dst *Move) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Move.Merge(dst, src)
+}
+func (m *Move) XXX_Size() int {
+	return m.Size()
+}
+func (m *Move) XXX_DiscardUnknown() {
+	xxx_messageInfo_Move.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Move proto.InternalMessageInfo
+
+func (m *Move) GetFrom() string {
+	if m != nil {
+		return m.From
 	}
-	return i, nil
+	return ""
 }
 
-func (m *Object_Blob) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Blob != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Blob.Size()))
-		n2, err := m.Blob.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n2
+func (m *Move) GetTo() string {
+	if m != nil {
+		return m.To
 	}
-	return i, nil
+	return ""
 }
-func (m *Object_Tree) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Tree != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Tree.Size()))
-		n3, err := m.Tree.MarshalTo(dAtA[i:])
+
+type WriteCommitRequest struct {
+	Repo    string            `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	Ref     string            `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	Upserts map[string][]byte `protobuf:"bytes,3,rep,name=upserts" json:"upserts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// files, directories whose files are all deleted, or glob patterns where
+	// `**` matches any number of directories and a pattern without a slash
+	// matches file names at any depth
+	Deletes []string `protobuf:"bytes,4,rep,name=deletes" json:"deletes,omitempty"`
+	Msg     string   `protobuf:"bytes,5,opt,name=msg,proto3" json:"msg,omitempty"`
+	// fail with a conflict unless the ref currently points at this commit
+	ExpectedParent string `protobuf:"bytes,6,opt,name=expectedParent,proto3" json:"expectedParent,omitempty"`
+	// fail with a conflict unless the ref does not exist yet
+	ExpectAbsent bool `protobuf:"varint,7,opt,name=expectAbsent,proto3" json:"expectAbsent,omitempty"`
+	// checked against the tree of the ref head
+	Preconditions []*Precondition `protobuf:"bytes,8,rep,name=preconditions" json:"preconditions,omitempty"`
+	// when expectedParent is stale, replay onto the ref head instead of failing
+	// provided none of the written paths changed since expectedParent
+	Rebase bool `protobuf:"varint,9,opt,name=rebase,proto3" json:"rebase,omitempty"`
+	// empty name and email fall back to the server identity; setting time
+	// requires the admin token
+	Author    *Signature `protobuf:"bytes,10,opt,name=author" json:"author,omitempty"`
+	Committer *Signature `protobuf:"bytes,11,opt,name=committer" json:"committer,omitempty"`
+	// appended to the message as git trailers, after a subject naming the
+	// changed paths if msg is empty
+	Metadata map[string]string `protobuf:"bytes,12,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// resolved against the tree of the ref head, none of the paths may overlap
+	// upserts, deletes or other moves
+	Moves []*Move `protobuf:"bytes,13,rep,name=moves" json:"moves,omitempty"`
+	// compute the result without storing any objects or moving the ref
+	DryRun bool `protobuf:"varint,14,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	// upserts with a file mode, none of the paths may repeat a path of upserts
+	Files []*Upsert `protobuf:"bytes,15,rep,name=files" json:"files,omitempty"`
+	// applied to the current content of existing files, failing the write if
+	// any of them does not apply; none of the paths may overlap any other path
+	// of the write
+	Patches []*Patch `protobuf:"bytes,16,rep,name=patches" json:"patches,omitempty"`
+	// a retried write with the same key returns the commit of the original
+	// write instead of writing again, provided that commit is on the ref and
+	// within the idempotency window of the server; stored as a trailer
+	IdempotencyKey string `protobuf:"bytes,17,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
+	// commit even if the tree does not change, instead of returning the ref
+	// head as unchanged
+	AllowEmpty bool `protobuf:"varint,18,opt,name=allowEmpty,proto3" json:"allowEmpty,omitempty"`
+	// include unified diffs in the changes of a dry run
+	TextDiff             bool     `protobuf:"varint,19,opt,name=textDiff,proto3" json:"textDiff,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteCommitRequest) Reset()         { *m = WriteCommitRequest{} }
+func (m *WriteCommitRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteCommitRequest) ProtoMessage()    {}
+func (*WriteCommitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{16}
+}
+func (m *WriteCommitRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WriteCommitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WriteCommitRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n3
+		return b[:n], nil
 	}
-	return i, nil
 }
-func (m *Object_Commit) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Commit != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Commit.Size()))
-		n4, err := m.Commit.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n4
-	}
-	return i, nil
+func (dst *WriteCommitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteCommitRequest.Merge(dst, src)
 }
-func (m *Blob) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *WriteCommitRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WriteCommitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteCommitRequest.DiscardUnknown(m)
 }
 
-func (m *Blob) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Hash) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Hash)))
-		i += copy(dAtA[i:], m.Hash)
-	}
-	if len(m.Content) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Content)))
-		i += copy(dAtA[i:], m.Content)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_WriteCommitRequest proto.InternalMessageInfo
+
+func (m *WriteCommitRequest) GetRepo() string {
+	if m != nil {
+		return m.Repo
 	}
-	return i, nil
+	return ""
 }
 
-func (m *TreeEntry) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *WriteCommitRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *TreeEntry) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+func (m *WriteCommitRequest) GetUpserts() map[string][]byte {
+	if m != nil {
+		return m.Upserts
 	}
-	if len(m.Hash) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Hash)))
-		i += copy(dAtA[i:], m.Hash)
+	return nil
+}
+
+func (m *WriteCommitRequest) GetDeletes() []string {
+	if m != nil {
+		return m.Deletes
 	}
-	if m.Mode != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Mode))
+	return nil
+}
+
+func (m *WriteCommitRequest) GetMsg() string {
+	if m != nil {
+		return m.Msg
 	}
-	if m.Entry != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Entry.Size()))
-		n5, err := m.Entry.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n5
+	return ""
+}
+
+func (m *WriteCommitRequest) GetExpectedParent() string {
+	if m != nil {
+		return m.ExpectedParent
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return ""
+}
+
+func (m *WriteCommitRequest) GetExpectAbsent() bool {
+	if m != nil {
+		return m.ExpectAbsent
 	}
-	return i, nil
+	return false
 }
 
-func (m *Tree) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *WriteCommitRequest) GetPreconditions() []*Precondition {
+	if m != nil {
+		return m.Preconditions
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Tree) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Hash) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Hash)))
-		i += copy(dAtA[i:], m.Hash)
+func (m *WriteCommitRequest) GetRebase() bool {
+	if m != nil {
+		return m.Rebase
 	}
-	if len(m.Entries) > 0 {
-		for _, msg := range m.Entries {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	return false
+}
+
+func (m *WriteCommitRequest) GetAuthor() *Signature {
+	if m != nil {
+		return m.Author
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return nil
+}
+
+func (m *WriteCommitRequest) GetCommitter() *Signature {
+	if m != nil {
+		return m.Committer
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Signature) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *WriteCommitRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Signature) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+func (m *WriteCommitRequest) GetMoves() []*Move {
+	if m != nil {
+		return m.Moves
 	}
-	if len(m.Email) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Email)))
-		i += copy(dAtA[i:], m.Email)
+	return nil
+}
+
+func (m *WriteCommitRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
 	}
-	if m.Time != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Time))
+	return false
+}
+
+func (m *WriteCommitRequest) GetFiles() []*Upsert {
+	if m != nil {
+		return m.Files
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return nil
+}
+
+func (m *WriteCommitRequest) GetPatches() []*Patch {
+	if m != nil {
+		return m.Patches
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Commit) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *WriteCommitRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Commit) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Hash) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Hash)))
-		i += copy(dAtA[i:], m.Hash)
+func (m *WriteCommitRequest) GetAllowEmpty() bool {
+	if m != nil {
+		return m.AllowEmpty
 	}
-	if m.Author != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Author.Size()))
-		n6, err := m.Author.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n6
+	return false
+}
+
+func (m *WriteCommitRequest) GetTextDiff() bool {
+	if m != nil {
+		return m.TextDiff
 	}
-	if m.Committer != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Committer.Size()))
-		n7, err := m.Committer.MarshalTo(dAtA[i:])
+	return false
+}
+
+type FileChange struct {
+	Path string     `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Type ChangeType `protobuf:"varint,2,opt,name=type,proto3,enum=gitpb.ChangeType" json:"type,omitempty"`
+	// blob hashes, empty for an added or deleted file respectively
+	OldHash              string   `protobuf:"bytes,3,opt,name=oldHash,proto3" json:"oldHash,omitempty"`
+	NewHash              string   `protobuf:"bytes,4,opt,name=newHash,proto3" json:"newHash,omitempty"`
+	Diff                 string   `protobuf:"bytes,5,opt,name=diff,proto3" json:"diff,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileChange) Reset()         { *m = FileChange{} }
+func (m *FileChange) String() string { return proto.CompactTextString(m) }
+func (*FileChange) ProtoMessage()    {}
+func (*FileChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{17}
+}
+func (m *FileChange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FileChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FileChange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n7
+		return b[:n], nil
 	}
-	if len(m.Message) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Message)))
-		i += copy(dAtA[i:], m.Message)
+}
+func (dst *FileChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileChange.Merge(dst, src)
+}
+func (m *FileChange) XXX_Size() int {
+	return m.Size()
+}
+func (m *FileChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileChange proto.InternalMessageInfo
+
+func (m *FileChange) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	if len(m.Tree) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Tree)))
-		i += copy(dAtA[i:], m.Tree)
+	return ""
+}
+
+func (m *FileChange) GetType() ChangeType {
+	if m != nil {
+		return m.Type
 	}
-	if len(m.Parents) > 0 {
-		for _, s := range m.Parents {
-			dAtA[i] = 0x32
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return ChangeType_ADDED
+}
+
+func (m *FileChange) GetOldHash() string {
+	if m != nil {
+		return m.OldHash
 	}
-	if m.TreeObject != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.TreeObject.Size()))
-		n8, err := m.TreeObject.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n8
+	return ""
+}
+
+func (m *FileChange) GetNewHash() string {
+	if m != nil {
+		return m.NewHash
 	}
-	if len(m.ParentObjects) > 0 {
-		for _, msg := range m.ParentObjects {
-			dAtA[i] = 0x42
-			i++
-			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	return ""
+}
+
+func (m *FileChange) GetDiff() string {
+	if m != nil {
+		return m.Diff
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return ""
+}
+
+type WriteCommitResponse struct {
+	Commit *Commit `protobuf:"bytes,1,opt,name=commit" json:"commit,omitempty"`
+	// whether the commit was replayed onto a head other than expectedParent
+	Rebased     bool   `protobuf:"varint,2,opt,name=rebased,proto3" json:"rebased,omitempty"`
+	RebasedOnto string `protobuf:"bytes,3,opt,name=rebasedOnto,proto3" json:"rebasedOnto,omitempty"`
+	// files removed by deletes
+	Deleted []string `protobuf:"bytes,4,rep,name=deleted" json:"deleted,omitempty"`
+	// whether the commit is the one of an earlier write with the same
+	// idempotency key
+	Replayed bool `protobuf:"varint,5,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	// whether the write changed nothing, in which case commit is the ref head
+	Unchanged bool `protobuf:"varint,6,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	// set for a dry run that changes the tree: the tree the commit would
+	// have and how it differs from the ref head
+	Tree                 string        `protobuf:"bytes,7,opt,name=tree,proto3" json:"tree,omitempty"`
+	Changes              []*FileChange `protobuf:"bytes,8,rep,name=changes" json:"changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *WriteCommitResponse) Reset()         { *m = WriteCommitResponse{} }
+func (m *WriteCommitResponse) String() string { return proto.CompactTextString(m) }
+func (*WriteCommitResponse) ProtoMessage()    {}
+func (*WriteCommitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{18}
+}
+func (m *WriteCommitResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WriteCommitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WriteCommitResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (dst *WriteCommitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteCommitResponse.Merge(dst, src)
+}
+func (m *WriteCommitResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *WriteCommitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteCommitResponse.DiscardUnknown(m)
 }
 
-func (m *ObjectIdentifier) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_WriteCommitResponse proto.InternalMessageInfo
+
+func (m *WriteCommitResponse) GetCommit() *Commit {
+	if m != nil {
+		return m.Commit
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ObjectIdentifier) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Repo) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Repo)))
-		i += copy(dAtA[i:], m.Repo)
+func (m *WriteCommitResponse) GetRebased() bool {
+	if m != nil {
+		return m.Rebased
 	}
-	if m.Type != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Type))
+	return false
+}
+
+func (m *WriteCommitResponse) GetRebasedOnto() string {
+	if m != nil {
+		return m.RebasedOnto
 	}
-	if len(m.Ref) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Ref)))
-		i += copy(dAtA[i:], m.Ref)
+	return ""
+}
+
+func (m *WriteCommitResponse) GetDeleted() []string {
+	if m != nil {
+		return m.Deleted
 	}
-	if len(m.Path) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Path)))
-		i += copy(dAtA[i:], m.Path)
+	return nil
+}
+
+func (m *WriteCommitResponse) GetReplayed() bool {
+	if m != nil {
+		return m.Replayed
 	}
-	if m.Time != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Time))
+	return false
+}
+
+func (m *WriteCommitResponse) GetUnchanged() bool {
+	if m != nil {
+		return m.Unchanged
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return false
+}
+
+func (m *WriteCommitResponse) GetTree() string {
+	if m != nil {
+		return m.Tree
 	}
-	return i, nil
+	return ""
 }
 
-func (m *GetObjectRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *WriteCommitResponse) GetChanges() []*FileChange {
+	if m != nil {
+		return m.Changes
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetObjectRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Id.Size()))
-		n9, err := m.Id.MarshalTo(dAtA[i:])
+type Repo struct {
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DefaultBranch string `protobuf:"bytes,2,opt,name=defaultBranch,proto3" json:"defaultBranch,omitempty"`
+	Head          string `protobuf:"bytes,3,opt,name=head,proto3" json:"head,omitempty"`
+	// bytes on disk
+	DiskSize int64 `protobuf:"varint,4,opt,name=diskSize,proto3" json:"diskSize,omitempty"`
+	// set when the repo exists on disk but failed to open
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Repo) Reset()         { *m = Repo{} }
+func (m *Repo) String() string { return proto.CompactTextString(m) }
+func (*Repo) ProtoMessage()    {}
+func (*Repo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{19}
+}
+func (m *Repo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Repo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Repo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n9
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+}
+func (dst *Repo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Repo.Merge(dst, src)
+}
+func (m *Repo) XXX_Size() int {
+	return m.Size()
+}
+func (m *Repo) XXX_DiscardUnknown() {
+	xxx_messageInfo_Repo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Repo proto.InternalMessageInfo
+
+func (m *Repo) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return i, nil
+	return ""
 }
 
-func (m *GetObjectResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Repo) GetDefaultBranch() string {
+	if m != nil {
+		return m.DefaultBranch
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *GetObjectResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Object != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Object.Size()))
-		n10, err := m.Object.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n10
+func (m *Repo) GetHead() string {
+	if m != nil {
+		return m.Head
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return ""
+}
+
+func (m *Repo) GetDiskSize() int64 {
+	if m != nil {
+		return m.DiskSize
 	}
-	return i, nil
+	return 0
 }
 
-func (m *WriteCommitRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Repo) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *WriteCommitRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Repo) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Repo)))
-		i += copy(dAtA[i:], m.Repo)
-	}
-	if len(m.Ref) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Ref)))
-		i += copy(dAtA[i:], m.Ref)
-	}
-	if len(m.Upserts) > 0 {
-		for k, _ := range m.Upserts {
-			dAtA[i] = 0x1a
-			i++
-			v := m.Upserts[k]
-			byteSize := 0
-			if len(v) > 0 {
-				byteSize = 1 + len(v) + sovGit(uint64(len(v)))
-			}
-			mapSize := 1 + len(k) + sovGit(uint64(len(k))) + byteSize
-			i = encodeVarintGit(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintGit(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			if len(v) > 0 {
-				dAtA[i] = 0x12
-				i++
-				i = encodeVarintGit(dAtA, i, uint64(len(v)))
-				i += copy(dAtA[i:], v)
-			}
-		}
-	}
-	if len(m.Deletes) > 0 {
-		for _, s := range m.Deletes {
-			dAtA[i] = 0x22
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+type CreateRepoRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// seed refs/heads/master with an empty commit
+	InitialCommit        bool     `protobuf:"varint,2,opt,name=initialCommit,proto3" json:"initialCommit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRepoRequest) Reset()         { *m = CreateRepoRequest{} }
+func (m *CreateRepoRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRepoRequest) ProtoMessage()    {}
+func (*CreateRepoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{20}
+}
+func (m *CreateRepoRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateRepoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateRepoRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.Msg) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(len(m.Msg)))
-		i += copy(dAtA[i:], m.Msg)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+}
+func (dst *CreateRepoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRepoRequest.Merge(dst, src)
+}
+func (m *CreateRepoRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateRepoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRepoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRepoRequest proto.InternalMessageInfo
+
+func (m *CreateRepoRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return i, nil
+	return ""
 }
 
-func (m *WriteCommitResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *CreateRepoRequest) GetInitialCommit() bool {
+	if m != nil {
+		return m.InitialCommit
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *WriteCommitResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Commit != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintGit(dAtA, i, uint64(m.Commit.Size()))
-		n11, err := m.Commit.MarshalTo(dAtA[i:])
+type CreateRepoResponse struct {
+	Repo                 *Repo    `protobuf:"bytes,1,opt,name=repo" json:"repo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRepoResponse) Reset()         { *m = CreateRepoResponse{} }
+func (m *CreateRepoResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateRepoResponse) ProtoMessage()    {}
+func (*CreateRepoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{21}
+}
+func (m *CreateRepoResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateRepoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateRepoResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n11
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (dst *CreateRepoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRepoResponse.Merge(dst, src)
+}
+func (m *CreateRepoResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateRepoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRepoResponse.DiscardUnknown(m)
 }
 
-func encodeVarintGit(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_CreateRepoResponse proto.InternalMessageInfo
+
+func (m *CreateRepoResponse) GetRepo() *Repo {
+	if m != nil {
+		return m.Repo
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return nil
 }
-func (m *Object) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Obj != nil {
-		n += m.Obj.Size()
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+
+// requires the admin token
+type DeleteRepoRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// move the repo aside instead of removing it
+	Archive              bool     `protobuf:"varint,2,opt,name=archive,proto3" json:"archive,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Object_Blob) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Blob != nil {
-		l = m.Blob.Size()
-		n += 1 + l + sovGit(uint64(l))
-	}
-	return n
+func (m *DeleteRepoRequest) Reset()         { *m = DeleteRepoRequest{} }
+func (m *DeleteRepoRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRepoRequest) ProtoMessage()    {}
+func (*DeleteRepoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{22}
 }
-func (m *Object_Tree) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Tree != nil {
-		l = m.Tree.Size()
-		n += 1 + l + sovGit(uint64(l))
-	}
-	return n
+func (m *DeleteRepoRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (m *Object_Commit) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Commit != nil {
-		l = m.Commit.Size()
-		n += 1 + l + sovGit(uint64(l))
+func (m *DeleteRepoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteRepoRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
 }
-func (m *Blob) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
-	}
-	l = len(m.Content)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (dst *DeleteRepoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRepoRequest.Merge(dst, src)
+}
+func (m *DeleteRepoRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteRepoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRepoRequest.DiscardUnknown(m)
 }
 
-func (m *TreeEntry) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
-	}
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
-	}
-	if m.Mode != 0 {
-		n += 1 + sovGit(uint64(m.Mode))
-	}
-	if m.Entry != nil {
-		l = m.Entry.Size()
-		n += 1 + l + sovGit(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+var xxx_messageInfo_DeleteRepoRequest proto.InternalMessageInfo
+
+func (m *DeleteRepoRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return n
+	return ""
 }
 
-func (m *Tree) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DeleteRepoRequest) GetArchive() bool {
+	if m != nil {
+		return m.Archive
 	}
-	var l int
-	_ = l
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
-	}
-	if len(m.Entries) > 0 {
-		for _, e := range m.Entries {
-			l = e.Size()
-			n += 1 + l + sovGit(uint64(l))
+	return false
+}
+
+type DeleteRepoResponse struct {
+	ArchivePath          string   `protobuf:"bytes,1,opt,name=archivePath,proto3" json:"archivePath,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRepoResponse) Reset()         { *m = DeleteRepoResponse{} }
+func (m *DeleteRepoResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteRepoResponse) ProtoMessage()    {}
+func (*DeleteRepoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{23}
+}
+func (m *DeleteRepoResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteRepoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteRepoResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+}
+func (dst *DeleteRepoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRepoResponse.Merge(dst, src)
+}
+func (m *DeleteRepoResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteRepoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRepoResponse.DiscardUnknown(m)
 }
 
-func (m *Signature) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
-	}
-	l = len(m.Email)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
-	}
-	if m.Time != 0 {
-		n += 1 + sovGit(uint64(m.Time))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+var xxx_messageInfo_DeleteRepoResponse proto.InternalMessageInfo
+
+func (m *DeleteRepoResponse) GetArchivePath() string {
+	if m != nil {
+		return m.ArchivePath
 	}
-	return n
+	return ""
 }
 
-func (m *Commit) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
-	}
-	if m.Author != nil {
-		l = m.Author.Size()
-		n += 1 + l + sovGit(uint64(l))
-	}
-	if m.Committer != nil {
-		l = m.Committer.Size()
-		n += 1 + l + sovGit(uint64(l))
-	}
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
-	}
-	l = len(m.Tree)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
+type ListReposRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListReposRequest) Reset()         { *m = ListReposRequest{} }
+func (m *ListReposRequest) String() string { return proto.CompactTextString(m) }
+func (*ListReposRequest) ProtoMessage()    {}
+func (*ListReposRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{24}
+}
+func (m *ListReposRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListReposRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListReposRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.Parents) > 0 {
-		for _, s := range m.Parents {
-			l = len(s)
-			n += 1 + l + sovGit(uint64(l))
+}
+func (dst *ListReposRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListReposRequest.Merge(dst, src)
+}
+func (m *ListReposRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListReposRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListReposRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListReposRequest proto.InternalMessageInfo
+
+type ListReposResponse struct {
+	Repos                []*Repo  `protobuf:"bytes,1,rep,name=repos" json:"repos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListReposResponse) Reset()         { *m = ListReposResponse{} }
+func (m *ListReposResponse) String() string { return proto.CompactTextString(m) }
+func (*ListReposResponse) ProtoMessage()    {}
+func (*ListReposResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{25}
+}
+func (m *ListReposResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListReposResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListReposResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.TreeObject != nil {
-		l = m.TreeObject.Size()
-		n += 1 + l + sovGit(uint64(l))
+}
+func (dst *ListReposResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListReposResponse.Merge(dst, src)
+}
+func (m *ListReposResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListReposResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListReposResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListReposResponse proto.InternalMessageInfo
+
+func (m *ListReposResponse) GetRepos() []*Repo {
+	if m != nil {
+		return m.Repos
 	}
-	if len(m.ParentObjects) > 0 {
-		for _, e := range m.ParentObjects {
-			l = e.Size()
-			n += 1 + l + sovGit(uint64(l))
+	return nil
+}
+
+type GetRepoRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRepoRequest) Reset()         { *m = GetRepoRequest{} }
+func (m *GetRepoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRepoRequest) ProtoMessage()    {}
+func (*GetRepoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{26}
+}
+func (m *GetRepoRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRepoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRepoRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (dst *GetRepoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRepoRequest.Merge(dst, src)
+}
+func (m *GetRepoRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRepoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRepoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRepoRequest proto.InternalMessageInfo
+
+func (m *GetRepoRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return n
+	return ""
 }
 
-func (m *ObjectIdentifier) Size() (n int) {
-	if m == nil {
-		return 0
+type GetRepoResponse struct {
+	Repo                 *Repo    `protobuf:"bytes,1,opt,name=repo" json:"repo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRepoResponse) Reset()         { *m = GetRepoResponse{} }
+func (m *GetRepoResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRepoResponse) ProtoMessage()    {}
+func (*GetRepoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{27}
+}
+func (m *GetRepoResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRepoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRepoResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	l = len(m.Repo)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
+}
+func (dst *GetRepoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRepoResponse.Merge(dst, src)
+}
+func (m *GetRepoResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRepoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRepoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRepoResponse proto.InternalMessageInfo
+
+func (m *GetRepoResponse) GetRepo() *Repo {
+	if m != nil {
+		return m.Repo
 	}
-	if m.Type != 0 {
-		n += 1 + sovGit(uint64(m.Type))
+	return nil
+}
+
+type ValidateTreeRequest struct {
+	Repo string `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	Ref  string `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	// validates the commit the ref pointed at this time, now if zero
+	Time                 int64     `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	Traversal            Traversal `protobuf:"varint,4,opt,name=traversal,proto3,enum=gitpb.Traversal" json:"traversal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ValidateTreeRequest) Reset()         { *m = ValidateTreeRequest{} }
+func (m *ValidateTreeRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidateTreeRequest) ProtoMessage()    {}
+func (*ValidateTreeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{28}
+}
+func (m *ValidateTreeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidateTreeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidateTreeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	l = len(m.Ref)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
+}
+func (dst *ValidateTreeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateTreeRequest.Merge(dst, src)
+}
+func (m *ValidateTreeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidateTreeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateTreeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateTreeRequest proto.InternalMessageInfo
+
+func (m *ValidateTreeRequest) GetRepo() string {
+	if m != nil {
+		return m.Repo
 	}
-	l = len(m.Path)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
+	return ""
+}
+
+func (m *ValidateTreeRequest) GetRef() string {
+	if m != nil {
+		return m.Ref
 	}
-	if m.Time != 0 {
-		n += 1 + sovGit(uint64(m.Time))
+	return ""
+}
+
+func (m *ValidateTreeRequest) GetTime() int64 {
+	if m != nil {
+		return m.Time
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return 0
+}
+
+func (m *ValidateTreeRequest) GetTraversal() Traversal {
+	if m != nil {
+		return m.Traversal
 	}
-	return n
+	return Traversal_FIRST_PARENT
 }
 
-func (m *GetObjectRequest) Size() (n int) {
-	if m == nil {
-		return 0
+type ValidationFailure struct {
+	// empty when the failure is not about a particular path
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidationFailure) Reset()         { *m = ValidationFailure{} }
+func (m *ValidationFailure) String() string { return proto.CompactTextString(m) }
+func (*ValidationFailure) ProtoMessage()    {}
+func (*ValidationFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{29}
+}
+func (m *ValidationFailure) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidationFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidationFailure.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovGit(uint64(l))
+}
+func (dst *ValidationFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidationFailure.Merge(dst, src)
+}
+func (m *ValidationFailure) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidationFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidationFailure.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidationFailure proto.InternalMessageInfo
+
+func (m *ValidationFailure) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return ""
+}
+
+func (m *ValidationFailure) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
-	return n
+	return ""
 }
 
-func (m *GetObjectResponse) Size() (n int) {
-	if m == nil {
-		return 0
+type ValidateTreeResponse struct {
+	// the commit whose tree was validated
+	Commit               string               `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	Failures             []*ValidationFailure `protobuf:"bytes,2,rep,name=failures" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ValidateTreeResponse) Reset()         { *m = ValidateTreeResponse{} }
+func (m *ValidateTreeResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidateTreeResponse) ProtoMessage()    {}
+func (*ValidateTreeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{30}
+}
+func (m *ValidateTreeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidateTreeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidateTreeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	if m.Object != nil {
-		l = m.Object.Size()
-		n += 1 + l + sovGit(uint64(l))
+}
+func (dst *ValidateTreeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateTreeResponse.Merge(dst, src)
+}
+func (m *ValidateTreeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidateTreeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateTreeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateTreeResponse proto.InternalMessageInfo
+
+func (m *ValidateTreeResponse) GetCommit() string {
+	if m != nil {
+		return m.Commit
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return ""
+}
+
+func (m *ValidateTreeResponse) GetFailures() []*ValidationFailure {
+	if m != nil {
+		return m.Failures
 	}
-	return n
+	return nil
 }
 
-func (m *WriteCommitRequest) Size() (n int) {
-	if m == nil {
-		return 0
+type ObjectPath struct {
+	Type                 ObjectType `protobuf:"varint,1,opt,name=type,proto3,enum=gitpb.ObjectType" json:"type,omitempty"`
+	Path                 string     `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ObjectPath) Reset()         { *m = ObjectPath{} }
+func (m *ObjectPath) String() string { return proto.CompactTextString(m) }
+func (*ObjectPath) ProtoMessage()    {}
+func (*ObjectPath) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{31}
+}
+func (m *ObjectPath) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ObjectPath) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ObjectPath.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	l = len(m.Repo)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
+}
+func (dst *ObjectPath) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectPath.Merge(dst, src)
+}
+func (m *ObjectPath) XXX_Size() int {
+	return m.Size()
+}
+func (m *ObjectPath) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectPath.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ObjectPath proto.InternalMessageInfo
+
+func (m *ObjectPath) GetType() ObjectType {
+	if m != nil {
+		return m.Type
 	}
-	l = len(m.Ref)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
+	return ObjectType_NONE
+}
+
+func (m *ObjectPath) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	if len(m.Upserts) > 0 {
-		for k, v := range m.Upserts {
-			_ = k
-			_ = v
-			l = 0
-			if len(v) > 0 {
-				l = 1 + len(v) + sovGit(uint64(len(v)))
-			}
-			mapEntrySize := 1 + len(k) + sovGit(uint64(len(k))) + l
-			n += mapEntrySize + 1 + sovGit(uint64(mapEntrySize))
+	return ""
+}
+
+type BatchGetObjectsRequest struct {
+	// the commit all objects are read from, its type and path are ignored
+	Snapshot *ObjectIdentifier `protobuf:"bytes,1,opt,name=snapshot" json:"snapshot,omitempty"`
+	// at most 1000 objects, larger requests are rejected
+	Objects []*ObjectPath `protobuf:"bytes,2,rep,name=objects" json:"objects,omitempty"`
+	// applies to every object, within one size budget for the whole response
+	Expand               *Expansion `protobuf:"bytes,3,opt,name=expand" json:"expand,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *BatchGetObjectsRequest) Reset()         { *m = BatchGetObjectsRequest{} }
+func (m *BatchGetObjectsRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchGetObjectsRequest) ProtoMessage()    {}
+func (*BatchGetObjectsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{32}
+}
+func (m *BatchGetObjectsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchGetObjectsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchGetObjectsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.Deletes) > 0 {
-		for _, s := range m.Deletes {
-			l = len(s)
-			n += 1 + l + sovGit(uint64(l))
+}
+func (dst *BatchGetObjectsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetObjectsRequest.Merge(dst, src)
+}
+func (m *BatchGetObjectsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchGetObjectsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetObjectsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetObjectsRequest proto.InternalMessageInfo
+
+func (m *BatchGetObjectsRequest) GetSnapshot() *ObjectIdentifier {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+func (m *BatchGetObjectsRequest) GetObjects() []*ObjectPath {
+	if m != nil {
+		return m.Objects
+	}
+	return nil
+}
+
+func (m *BatchGetObjectsRequest) GetExpand() *Expansion {
+	if m != nil {
+		return m.Expand
+	}
+	return nil
+}
+
+type BatchObject struct {
+	Type ObjectType `protobuf:"varint,1,opt,name=type,proto3,enum=gitpb.ObjectType" json:"type,omitempty"`
+	Path string     `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// unset if reading the object failed
+	Object *Object `protobuf:"bytes,3,opt,name=object" json:"object,omitempty"`
+	// gRPC status code and message of the failure, OK if there was none
+	Code                 int32    `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchObject) Reset()         { *m = BatchObject{} }
+func (m *BatchObject) String() string { return proto.CompactTextString(m) }
+func (*BatchObject) ProtoMessage()    {}
+func (*BatchObject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{33}
+}
+func (m *BatchObject) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchObject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchObject.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *BatchObject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchObject.Merge(dst, src)
+}
+func (m *BatchObject) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchObject) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchObject.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchObject proto.InternalMessageInfo
+
+func (m *BatchObject) GetType() ObjectType {
+	if m != nil {
+		return m.Type
+	}
+	return ObjectType_NONE
+}
+
+func (m *BatchObject) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *BatchObject) GetObject() *Object {
+	if m != nil {
+		return m.Object
+	}
+	return nil
+}
+
+func (m *BatchObject) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *BatchObject) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type BatchGetObjectsResponse struct {
+	// the commit all objects were read from
+	Commit string `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	// in the order of the request
+	Objects []*BatchObject `protobuf:"bytes,2,rep,name=objects" json:"objects,omitempty"`
+	// set if expansions were left out to keep the response within the size
+	// limit
+	Truncated            bool     `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchGetObjectsResponse) Reset()         { *m = BatchGetObjectsResponse{} }
+func (m *BatchGetObjectsResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchGetObjectsResponse) ProtoMessage()    {}
+func (*BatchGetObjectsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_git_0d1a215aa3c73a40, []int{34}
+}
+func (m *BatchGetObjectsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchGetObjectsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchGetObjectsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *BatchGetObjectsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetObjectsResponse.Merge(dst, src)
+}
+func (m *BatchGetObjectsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchGetObjectsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetObjectsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetObjectsResponse proto.InternalMessageInfo
+
+func (m *BatchGetObjectsResponse) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *BatchGetObjectsResponse) GetObjects() []*BatchObject {
+	if m != nil {
+		return m.Objects
+	}
+	return nil
+}
+
+func (m *BatchGetObjectsResponse) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*Object)(nil), "gitpb.Object")
+	proto.RegisterType((*Blob)(nil), "gitpb.Blob")
+	proto.RegisterType((*TreeEntry)(nil), "gitpb.TreeEntry")
+	proto.RegisterType((*Tree)(nil), "gitpb.Tree")
+	proto.RegisterType((*Signature)(nil), "gitpb.Signature")
+	proto.RegisterType((*Commit)(nil), "gitpb.Commit")
+	proto.RegisterMapType((map[string]string)(nil), "gitpb.Commit.TrailersEntry")
+	proto.RegisterType((*ObjectIdentifier)(nil), "gitpb.ObjectIdentifier")
+	proto.RegisterType((*GetObjectRequest)(nil), "gitpb.GetObjectRequest")
+	proto.RegisterType((*Expansion)(nil), "gitpb.Expansion")
+	proto.RegisterType((*GetObjectResponse)(nil), "gitpb.GetObjectResponse")
+	proto.RegisterType((*VerificationFailure)(nil), "gitpb.VerificationFailure")
+	proto.RegisterType((*Verification)(nil), "gitpb.Verification")
+	proto.RegisterType((*Precondition)(nil), "gitpb.Precondition")
+	proto.RegisterType((*Upsert)(nil), "gitpb.Upsert")
+	proto.RegisterType((*Patch)(nil), "gitpb.Patch")
+	proto.RegisterType((*Move)(nil), "gitpb.Move")
+	proto.RegisterType((*WriteCommitRequest)(nil), "gitpb.WriteCommitRequest")
+	proto.RegisterMapType((map[string]string)(nil), "gitpb.WriteCommitRequest.MetadataEntry")
+	proto.RegisterMapType((map[string][]byte)(nil), "gitpb.WriteCommitRequest.UpsertsEntry")
+	proto.RegisterType((*FileChange)(nil), "gitpb.FileChange")
+	proto.RegisterType((*WriteCommitResponse)(nil), "gitpb.WriteCommitResponse")
+	proto.RegisterType((*Repo)(nil), "gitpb.Repo")
+	proto.RegisterType((*CreateRepoRequest)(nil), "gitpb.CreateRepoRequest")
+	proto.RegisterType((*CreateRepoResponse)(nil), "gitpb.CreateRepoResponse")
+	proto.RegisterType((*DeleteRepoRequest)(nil), "gitpb.DeleteRepoRequest")
+	proto.RegisterType((*DeleteRepoResponse)(nil), "gitpb.DeleteRepoResponse")
+	proto.RegisterType((*ListReposRequest)(nil), "gitpb.ListReposRequest")
+	proto.RegisterType((*ListReposResponse)(nil), "gitpb.ListReposResponse")
+	proto.RegisterType((*GetRepoRequest)(nil), "gitpb.GetRepoRequest")
+	proto.RegisterType((*GetRepoResponse)(nil), "gitpb.GetRepoResponse")
+	proto.RegisterType((*ValidateTreeRequest)(nil), "gitpb.ValidateTreeRequest")
+	proto.RegisterType((*ValidationFailure)(nil), "gitpb.ValidationFailure")
+	proto.RegisterType((*ValidateTreeResponse)(nil), "gitpb.ValidateTreeResponse")
+	proto.RegisterType((*ObjectPath)(nil), "gitpb.ObjectPath")
+	proto.RegisterType((*BatchGetObjectsRequest)(nil), "gitpb.BatchGetObjectsRequest")
+	proto.RegisterType((*BatchObject)(nil), "gitpb.BatchObject")
+	proto.RegisterType((*BatchGetObjectsResponse)(nil), "gitpb.BatchGetObjectsResponse")
+	proto.RegisterEnum("gitpb.FileMode", FileMode_name, FileMode_value)
+	proto.RegisterEnum("gitpb.ObjectType", ObjectType_name, ObjectType_value)
+	proto.RegisterEnum("gitpb.Traversal", Traversal_name, Traversal_value)
+	proto.RegisterEnum("gitpb.PatchType", PatchType_name, PatchType_value)
+	proto.RegisterEnum("gitpb.ChangeType", ChangeType_name, ChangeType_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// GitClient is the client API for Git service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type GitClient interface {
+	Health(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetObject(ctx context.Context, in *GetObjectRequest, opts ...grpc.CallOption) (*GetObjectResponse, error)
+	WriteCommit(ctx context.Context, in *WriteCommitRequest, opts ...grpc.CallOption) (*WriteCommitResponse, error)
+	CreateRepo(ctx context.Context, in *CreateRepoRequest, opts ...grpc.CallOption) (*CreateRepoResponse, error)
+	DeleteRepo(ctx context.Context, in *DeleteRepoRequest, opts ...grpc.CallOption) (*DeleteRepoResponse, error)
+	ListRepos(ctx context.Context, in *ListReposRequest, opts ...grpc.CallOption) (*ListReposResponse, error)
+	GetRepo(ctx context.Context, in *GetRepoRequest, opts ...grpc.CallOption) (*GetRepoResponse, error)
+	ValidateTree(ctx context.Context, in *ValidateTreeRequest, opts ...grpc.CallOption) (*ValidateTreeResponse, error)
+	BatchGetObjects(ctx context.Context, in *BatchGetObjectsRequest, opts ...grpc.CallOption) (*BatchGetObjectsResponse, error)
+}
+
+type gitClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGitClient(cc *grpc.ClientConn) GitClient {
+	return &gitClient{cc}
+}
+
+func (c *gitClient) Health(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/gitpb.Git/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitClient) GetObject(ctx context.Context, in *GetObjectRequest, opts ...grpc.CallOption) (*GetObjectResponse, error) {
+	out := new(GetObjectResponse)
+	err := c.cc.Invoke(ctx, "/gitpb.Git/GetObject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitClient) WriteCommit(ctx context.Context, in *WriteCommitRequest, opts ...grpc.CallOption) (*WriteCommitResponse, error) {
+	out := new(WriteCommitResponse)
+	err := c.cc.Invoke(ctx, "/gitpb.Git/WriteCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitClient) CreateRepo(ctx context.Context, in *CreateRepoRequest, opts ...grpc.CallOption) (*CreateRepoResponse, error) {
+	out := new(CreateRepoResponse)
+	err := c.cc.Invoke(ctx, "/gitpb.Git/CreateRepo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitClient) DeleteRepo(ctx context.Context, in *DeleteRepoRequest, opts ...grpc.CallOption) (*DeleteRepoResponse, error) {
+	out := new(DeleteRepoResponse)
+	err := c.cc.Invoke(ctx, "/gitpb.Git/DeleteRepo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitClient) ListRepos(ctx context.Context, in *ListReposRequest, opts ...grpc.CallOption) (*ListReposResponse, error) {
+	out := new(ListReposResponse)
+	err := c.cc.Invoke(ctx, "/gitpb.Git/ListRepos", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitClient) GetRepo(ctx context.Context, in *GetRepoRequest, opts ...grpc.CallOption) (*GetRepoResponse, error) {
+	out := new(GetRepoResponse)
+	err := c.cc.Invoke(ctx, "/gitpb.Git/GetRepo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitClient) ValidateTree(ctx context.Context, in *ValidateTreeRequest, opts ...grpc.CallOption) (*ValidateTreeResponse, error) {
+	out := new(ValidateTreeResponse)
+	err := c.cc.Invoke(ctx, "/gitpb.Git/ValidateTree", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitClient) BatchGetObjects(ctx context.Context, in *BatchGetObjectsRequest, opts ...grpc.CallOption) (*BatchGetObjectsResponse, error) {
+	out := new(BatchGetObjectsResponse)
+	err := c.cc.Invoke(ctx, "/gitpb.Git/BatchGetObjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GitServer is the server API for Git service.
+type GitServer interface {
+	Health(context.Context, *empty.Empty) (*empty.Empty, error)
+	GetObject(context.Context, *GetObjectRequest) (*GetObjectResponse, error)
+	WriteCommit(context.Context, *WriteCommitRequest) (*WriteCommitResponse, error)
+	CreateRepo(context.Context, *CreateRepoRequest) (*CreateRepoResponse, error)
+	DeleteRepo(context.Context, *DeleteRepoRequest) (*DeleteRepoResponse, error)
+	ListRepos(context.Context, *ListReposRequest) (*ListReposResponse, error)
+	GetRepo(context.Context, *GetRepoRequest) (*GetRepoResponse, error)
+	ValidateTree(context.Context, *ValidateTreeRequest) (*ValidateTreeResponse, error)
+	BatchGetObjects(context.Context, *BatchGetObjectsRequest) (*BatchGetObjectsResponse, error)
+}
+
+func RegisterGitServer(s *grpc.Server, srv GitServer) {
+	s.RegisterService(&_Git_serviceDesc, srv)
+}
+
+func _Git_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpb.Git/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitServer).Health(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Git_GetObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitServer).GetObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpb.Git/GetObject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitServer).GetObject(ctx, req.(*GetObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Git_WriteCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitServer).WriteCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpb.Git/WriteCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitServer).WriteCommit(ctx, req.(*WriteCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Git_CreateRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRepoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitServer).CreateRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpb.Git/CreateRepo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitServer).CreateRepo(ctx, req.(*CreateRepoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Git_DeleteRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRepoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitServer).DeleteRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpb.Git/DeleteRepo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitServer).DeleteRepo(ctx, req.(*DeleteRepoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Git_ListRepos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReposRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitServer).ListRepos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpb.Git/ListRepos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitServer).ListRepos(ctx, req.(*ListReposRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Git_GetRepo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRepoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitServer).GetRepo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpb.Git/GetRepo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitServer).GetRepo(ctx, req.(*GetRepoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Git_ValidateTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitServer).ValidateTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpb.Git/ValidateTree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitServer).ValidateTree(ctx, req.(*ValidateTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Git_BatchGetObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitServer).BatchGetObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitpb.Git/BatchGetObjects",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitServer).BatchGetObjects(ctx, req.(*BatchGetObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Git_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "gitpb.Git",
+	HandlerType: (*GitServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Health",
+			Handler:    _Git_Health_Handler,
+		},
+		{
+			MethodName: "GetObject",
+			Handler:    _Git_GetObject_Handler,
+		},
+		{
+			MethodName: "WriteCommit",
+			Handler:    _Git_WriteCommit_Handler,
+		},
+		{
+			MethodName: "CreateRepo",
+			Handler:    _Git_CreateRepo_Handler,
+		},
+		{
+			MethodName: "DeleteRepo",
+			Handler:    _Git_DeleteRepo_Handler,
+		},
+		{
+			MethodName: "ListRepos",
+			Handler:    _Git_ListRepos_Handler,
+		},
+		{
+			MethodName: "GetRepo",
+			Handler:    _Git_GetRepo_Handler,
+		},
+		{
+			MethodName: "ValidateTree",
+			Handler:    _Git_ValidateTree_Handler,
+		},
+		{
+			MethodName: "BatchGetObjects",
+			Handler:    _Git_BatchGetObjects_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "git.proto",
+}
+
+func (m *Object) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Object) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Obj != nil {
+		nn1, err := m.Obj.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn1
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Object_Blob) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Blob != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Blob.Size()))
+		n2, err := m.Blob.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	return i, nil
+}
+func (m *Object_Tree) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Tree != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Tree.Size()))
+		n3, err := m.Tree.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	return i, nil
+}
+func (m *Object_Commit) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Commit != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Commit.Size()))
+		n4, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	return i, nil
+}
+func (m *Blob) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Blob) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	if len(m.Content) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Content)))
+		i += copy(dAtA[i:], m.Content)
+	}
+	if m.Mode != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Mode))
+	}
+	if len(m.SymlinkTarget) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.SymlinkTarget)))
+		i += copy(dAtA[i:], m.SymlinkTarget)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TreeEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TreeEntry) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	if m.Mode != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Mode))
+	}
+	if m.Entry != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Entry.Size()))
+		n5, err := m.Entry.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if len(m.SymlinkTarget) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.SymlinkTarget)))
+		i += copy(dAtA[i:], m.SymlinkTarget)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Tree) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Tree) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	if len(m.Entries) > 0 {
+		for _, msg := range m.Entries {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Signature) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Signature) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Email) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Email)))
+		i += copy(dAtA[i:], m.Email)
+	}
+	if m.Time != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Time))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Commit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Commit) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	if m.Author != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Author.Size()))
+		n6, err := m.Author.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.Committer != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Committer.Size()))
+		n7, err := m.Committer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if len(m.Message) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
+	}
+	if len(m.Tree) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Tree)))
+		i += copy(dAtA[i:], m.Tree)
+	}
+	if len(m.Parents) > 0 {
+		for _, s := range m.Parents {
+			dAtA[i] = 0x32
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.TreeObject != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.TreeObject.Size()))
+		n8, err := m.TreeObject.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	if len(m.ParentObjects) > 0 {
+		for _, msg := range m.ParentObjects {
+			dAtA[i] = 0x42
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Trailers) > 0 {
+		for k, _ := range m.Trailers {
+			dAtA[i] = 0x4a
+			i++
+			v := m.Trailers[k]
+			mapSize := 1 + len(k) + sovGit(uint64(len(k))) + 1 + len(v) + sovGit(uint64(len(v)))
+			i = encodeVarintGit(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if len(m.Signature) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Signature)))
+		i += copy(dAtA[i:], m.Signature)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ObjectIdentifier) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ObjectIdentifier) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Repo) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Repo)))
+		i += copy(dAtA[i:], m.Repo)
+	}
+	if m.Type != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Type))
+	}
+	if len(m.Ref) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Ref)))
+		i += copy(dAtA[i:], m.Ref)
+	}
+	if len(m.Path) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if m.Time != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Time))
+	}
+	if m.Traversal != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Traversal))
+	}
+	if len(m.Revision) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Revision)))
+		i += copy(dAtA[i:], m.Revision)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetObjectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetObjectRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Id != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Id.Size()))
+		n9, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if m.Verify {
+		dAtA[i] = 0x10
+		i++
+		if m.Verify {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Expand != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Expand.Size()))
+		n10, err := m.Expand.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Expansion) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Expansion) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.TreeDepth != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.TreeDepth))
+	}
+	if m.Blobs {
+		dAtA[i] = 0x10
+		i++
+		if m.Blobs {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.MaxBlobBytes != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.MaxBlobBytes))
+	}
+	if m.Parents != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Parents))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetObjectResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetObjectResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Object != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Object.Size()))
+		n11, err := m.Object.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	if m.Verification != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Verification.Size()))
+		n12, err := m.Verification.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	if m.Truncated {
+		dAtA[i] = 0x18
+		i++
+		if m.Truncated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *VerificationFailure) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerificationFailure) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Commit) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Commit)))
+		i += copy(dAtA[i:], m.Commit)
+	}
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Verification) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Verification) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Valid {
+		dAtA[i] = 0x8
+		i++
+		if m.Valid {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Checked != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Checked))
+	}
+	if len(m.Failures) > 0 {
+		for _, msg := range m.Failures {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Truncated {
+		dAtA[i] = 0x20
+		i++
+		if m.Truncated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Precondition) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Precondition) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	if m.Absent {
+		dAtA[i] = 0x18
+		i++
+		if m.Absent {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Upsert) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Upsert) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if len(m.Content) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Content)))
+		i += copy(dAtA[i:], m.Content)
+	}
+	if m.Mode != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Mode))
+	}
+	if len(m.Gitlink) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Gitlink)))
+		i += copy(dAtA[i:], m.Gitlink)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Patch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Patch) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if m.Type != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Type))
+	}
+	if len(m.Patch) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Patch)))
+		i += copy(dAtA[i:], m.Patch)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Move) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Move) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.From) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.From)))
+		i += copy(dAtA[i:], m.From)
+	}
+	if len(m.To) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.To)))
+		i += copy(dAtA[i:], m.To)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *WriteCommitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WriteCommitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Repo) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Repo)))
+		i += copy(dAtA[i:], m.Repo)
+	}
+	if len(m.Ref) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Ref)))
+		i += copy(dAtA[i:], m.Ref)
+	}
+	if len(m.Upserts) > 0 {
+		for k, _ := range m.Upserts {
+			dAtA[i] = 0x1a
+			i++
+			v := m.Upserts[k]
+			byteSize := 0
+			if len(v) > 0 {
+				byteSize = 1 + len(v) + sovGit(uint64(len(v)))
+			}
+			mapSize := 1 + len(k) + sovGit(uint64(len(k))) + byteSize
+			i = encodeVarintGit(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			if len(v) > 0 {
+				dAtA[i] = 0x12
+				i++
+				i = encodeVarintGit(dAtA, i, uint64(len(v)))
+				i += copy(dAtA[i:], v)
+			}
+		}
+	}
+	if len(m.Deletes) > 0 {
+		for _, s := range m.Deletes {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Msg) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Msg)))
+		i += copy(dAtA[i:], m.Msg)
+	}
+	if len(m.ExpectedParent) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.ExpectedParent)))
+		i += copy(dAtA[i:], m.ExpectedParent)
+	}
+	if m.ExpectAbsent {
+		dAtA[i] = 0x38
+		i++
+		if m.ExpectAbsent {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Preconditions) > 0 {
+		for _, msg := range m.Preconditions {
+			dAtA[i] = 0x42
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Rebase {
+		dAtA[i] = 0x48
+		i++
+		if m.Rebase {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Author != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Author.Size()))
+		n13, err := m.Author.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	if m.Committer != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Committer.Size()))
+		n14, err := m.Committer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if len(m.Metadata) > 0 {
+		for k, _ := range m.Metadata {
+			dAtA[i] = 0x62
+			i++
+			v := m.Metadata[k]
+			mapSize := 1 + len(k) + sovGit(uint64(len(k))) + 1 + len(v) + sovGit(uint64(len(v)))
+			i = encodeVarintGit(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if len(m.Moves) > 0 {
+		for _, msg := range m.Moves {
+			dAtA[i] = 0x6a
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.DryRun {
+		dAtA[i] = 0x70
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Files) > 0 {
+		for _, msg := range m.Files {
+			dAtA[i] = 0x7a
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Patches) > 0 {
+		for _, msg := range m.Patches {
+			dAtA[i] = 0x82
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.IdempotencyKey) > 0 {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.IdempotencyKey)))
+		i += copy(dAtA[i:], m.IdempotencyKey)
+	}
+	if m.AllowEmpty {
+		dAtA[i] = 0x90
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.AllowEmpty {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.TextDiff {
+		dAtA[i] = 0x98
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.TextDiff {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *FileChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FileChange) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if m.Type != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Type))
+	}
+	if len(m.OldHash) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.OldHash)))
+		i += copy(dAtA[i:], m.OldHash)
+	}
+	if len(m.NewHash) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.NewHash)))
+		i += copy(dAtA[i:], m.NewHash)
+	}
+	if len(m.Diff) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Diff)))
+		i += copy(dAtA[i:], m.Diff)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *WriteCommitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WriteCommitResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Commit != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Commit.Size()))
+		n15, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.Rebased {
+		dAtA[i] = 0x10
+		i++
+		if m.Rebased {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.RebasedOnto) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.RebasedOnto)))
+		i += copy(dAtA[i:], m.RebasedOnto)
+	}
+	if len(m.Deleted) > 0 {
+		for _, s := range m.Deleted {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Replayed {
+		dAtA[i] = 0x28
+		i++
+		if m.Replayed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Unchanged {
+		dAtA[i] = 0x30
+		i++
+		if m.Unchanged {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Tree) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Tree)))
+		i += copy(dAtA[i:], m.Tree)
+	}
+	if len(m.Changes) > 0 {
+		for _, msg := range m.Changes {
+			dAtA[i] = 0x42
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Repo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Repo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.DefaultBranch) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.DefaultBranch)))
+		i += copy(dAtA[i:], m.DefaultBranch)
+	}
+	if len(m.Head) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Head)))
+		i += copy(dAtA[i:], m.Head)
+	}
+	if m.DiskSize != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.DiskSize))
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CreateRepoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateRepoRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.InitialCommit {
+		dAtA[i] = 0x10
+		i++
+		if m.InitialCommit {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CreateRepoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateRepoResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Repo != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Repo.Size()))
+		n16, err := m.Repo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DeleteRepoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteRepoRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Archive {
+		dAtA[i] = 0x10
+		i++
+		if m.Archive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DeleteRepoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteRepoResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ArchivePath) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.ArchivePath)))
+		i += copy(dAtA[i:], m.ArchivePath)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ListReposRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListReposRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ListReposResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListReposResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Repos) > 0 {
+		for _, msg := range m.Repos {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetRepoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRepoRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetRepoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRepoResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Repo != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Repo.Size()))
+		n17, err := m.Repo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ValidateTreeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidateTreeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Repo) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Repo)))
+		i += copy(dAtA[i:], m.Repo)
+	}
+	if len(m.Ref) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Ref)))
+		i += copy(dAtA[i:], m.Ref)
+	}
+	if m.Time != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Time))
+	}
+	if m.Traversal != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Traversal))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ValidationFailure) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidationFailure) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ValidateTreeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidateTreeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Commit) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Commit)))
+		i += copy(dAtA[i:], m.Commit)
+	}
+	if len(m.Failures) > 0 {
+		for _, msg := range m.Failures {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ObjectPath) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ObjectPath) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Type != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Type))
+	}
+	if len(m.Path) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *BatchGetObjectsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchGetObjectsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Snapshot != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Snapshot.Size()))
+		n18, err := m.Snapshot.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	if len(m.Objects) > 0 {
+		for _, msg := range m.Objects {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Expand != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Expand.Size()))
+		n19, err := m.Expand.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *BatchObject) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchObject) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Type != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Type))
+	}
+	if len(m.Path) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if m.Object != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Object.Size()))
+		n20, err := m.Object.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
+	}
+	if m.Code != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(m.Code))
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *BatchGetObjectsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchGetObjectsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Commit) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintGit(dAtA, i, uint64(len(m.Commit)))
+		i += copy(dAtA[i:], m.Commit)
+	}
+	if len(m.Objects) > 0 {
+		for _, msg := range m.Objects {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintGit(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Truncated {
+		dAtA[i] = 0x18
+		i++
+		if m.Truncated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintGit(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Object) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Obj != nil {
+		n += m.Obj.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Object_Blob) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Blob != nil {
+		l = m.Blob.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	return n
+}
+func (m *Object_Tree) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Tree != nil {
+		l = m.Tree.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	return n
+}
+func (m *Object_Commit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	return n
+}
+func (m *Blob) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Content)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Mode != 0 {
+		n += 1 + sovGit(uint64(m.Mode))
+	}
+	l = len(m.SymlinkTarget)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TreeEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Mode != 0 {
+		n += 1 + sovGit(uint64(m.Mode))
+	}
+	if m.Entry != nil {
+		l = m.Entry.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.SymlinkTarget)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Tree) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Signature) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Email)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Time != 0 {
+		n += 1 + sovGit(uint64(m.Time))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Commit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Author != nil {
+		l = m.Author.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Committer != nil {
+		l = m.Committer.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Tree)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if len(m.Parents) > 0 {
+		for _, s := range m.Parents {
+			l = len(s)
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if m.TreeObject != nil {
+		l = m.TreeObject.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if len(m.ParentObjects) > 0 {
+		for _, e := range m.ParentObjects {
+			l = e.Size()
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if len(m.Trailers) > 0 {
+		for k, v := range m.Trailers {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovGit(uint64(len(k))) + 1 + len(v) + sovGit(uint64(len(v)))
+			n += mapEntrySize + 1 + sovGit(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ObjectIdentifier) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Repo)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovGit(uint64(m.Type))
+	}
+	l = len(m.Ref)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Time != 0 {
+		n += 1 + sovGit(uint64(m.Time))
+	}
+	if m.Traversal != 0 {
+		n += 1 + sovGit(uint64(m.Traversal))
+	}
+	l = len(m.Revision)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetObjectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Verify {
+		n += 2
+	}
+	if m.Expand != nil {
+		l = m.Expand.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Expansion) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TreeDepth != 0 {
+		n += 1 + sovGit(uint64(m.TreeDepth))
+	}
+	if m.Blobs {
+		n += 2
+	}
+	if m.MaxBlobBytes != 0 {
+		n += 1 + sovGit(uint64(m.MaxBlobBytes))
+	}
+	if m.Parents != 0 {
+		n += 1 + sovGit(uint64(m.Parents))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetObjectResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Object != nil {
+		l = m.Object.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Verification != nil {
+		l = m.Verification.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Truncated {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *VerificationFailure) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Commit)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Verification) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Valid {
+		n += 2
+	}
+	if m.Checked != 0 {
+		n += 1 + sovGit(uint64(m.Checked))
+	}
+	if len(m.Failures) > 0 {
+		for _, e := range m.Failures {
+			l = e.Size()
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if m.Truncated {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Precondition) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Absent {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Upsert) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Content)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Mode != 0 {
+		n += 1 + sovGit(uint64(m.Mode))
+	}
+	l = len(m.Gitlink)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Patch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovGit(uint64(m.Type))
+	}
+	l = len(m.Patch)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Move) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.From)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.To)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WriteCommitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Repo)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Ref)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if len(m.Upserts) > 0 {
+		for k, v := range m.Upserts {
+			_ = k
+			_ = v
+			l = 0
+			if len(v) > 0 {
+				l = 1 + len(v) + sovGit(uint64(len(v)))
+			}
+			mapEntrySize := 1 + len(k) + sovGit(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovGit(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Deletes) > 0 {
+		for _, s := range m.Deletes {
+			l = len(s)
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.ExpectedParent)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.ExpectAbsent {
+		n += 2
+	}
+	if len(m.Preconditions) > 0 {
+		for _, e := range m.Preconditions {
+			l = e.Size()
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if m.Rebase {
+		n += 2
+	}
+	if m.Author != nil {
+		l = m.Author.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Committer != nil {
+		l = m.Committer.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if len(m.Metadata) > 0 {
+		for k, v := range m.Metadata {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovGit(uint64(len(k))) + 1 + len(v) + sovGit(uint64(len(v)))
+			n += mapEntrySize + 1 + sovGit(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Moves) > 0 {
+		for _, e := range m.Moves {
+			l = e.Size()
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if m.DryRun {
+		n += 2
+	}
+	if len(m.Files) > 0 {
+		for _, e := range m.Files {
+			l = e.Size()
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if len(m.Patches) > 0 {
+		for _, e := range m.Patches {
+			l = e.Size()
+			n += 2 + l + sovGit(uint64(l))
+		}
+	}
+	l = len(m.IdempotencyKey)
+	if l > 0 {
+		n += 2 + l + sovGit(uint64(l))
+	}
+	if m.AllowEmpty {
+		n += 3
+	}
+	if m.TextDiff {
+		n += 3
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FileChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovGit(uint64(m.Type))
+	}
+	l = len(m.OldHash)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.NewHash)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Diff)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WriteCommitResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Rebased {
+		n += 2
+	}
+	l = len(m.RebasedOnto)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if len(m.Deleted) > 0 {
+		for _, s := range m.Deleted {
+			l = len(s)
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if m.Replayed {
+		n += 2
+	}
+	if m.Unchanged {
+		n += 2
+	}
+	l = len(m.Tree)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if len(m.Changes) > 0 {
+		for _, e := range m.Changes {
+			l = e.Size()
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Repo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.DefaultBranch)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Head)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.DiskSize != 0 {
+		n += 1 + sovGit(uint64(m.DiskSize))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateRepoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.InitialCommit {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateRepoResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteRepoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Archive {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteRepoResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ArchivePath)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListReposRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListReposResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Repos) > 0 {
+		for _, e := range m.Repos {
+			l = e.Size()
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetRepoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetRepoResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Repo != nil {
+		l = m.Repo.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ValidateTreeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Repo)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Ref)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Time != 0 {
+		n += 1 + sovGit(uint64(m.Time))
+	}
+	if m.Traversal != 0 {
+		n += 1 + sovGit(uint64(m.Traversal))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ValidationFailure) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ValidateTreeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Commit)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if len(m.Failures) > 0 {
+		for _, e := range m.Failures {
+			l = e.Size()
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ObjectPath) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovGit(uint64(m.Type))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchGetObjectsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Snapshot != nil {
+		l = m.Snapshot.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if len(m.Objects) > 0 {
+		for _, e := range m.Objects {
+			l = e.Size()
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if m.Expand != nil {
+		l = m.Expand.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchObject) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovGit(uint64(m.Type))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Object != nil {
+		l = m.Object.Size()
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.Code != 0 {
+		n += 1 + sovGit(uint64(m.Code))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchGetObjectsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Commit)
+	if l > 0 {
+		n += 1 + l + sovGit(uint64(l))
+	}
+	if len(m.Objects) > 0 {
+		for _, e := range m.Objects {
+			l = e.Size()
+			n += 1 + l + sovGit(uint64(l))
+		}
+	}
+	if m.Truncated {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovGit(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozGit(x uint64) (n int) {
+	return sovGit(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Object) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Object: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Object: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Blob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Blob{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Obj = &Object_Blob{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Tree{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Obj = &Object_Tree{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Commit{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Obj = &Object_Commit{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Blob) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Blob: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Blob: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Content = append(m.Content[:0], dAtA[iNdEx:postIndex]...)
+			if m.Content == nil {
+				m.Content = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			m.Mode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mode |= (FileMode(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SymlinkTarget", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SymlinkTarget = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TreeEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TreeEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TreeEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			m.Mode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mode |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entry", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Entry == nil {
+				m.Entry = &Object{}
+			}
+			if err := m.Entry.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SymlinkTarget", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SymlinkTarget = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Tree) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Tree: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Tree: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries, &TreeEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Signature) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Signature: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Signature: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Email = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Commit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Commit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Commit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Author", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Author == nil {
+				m.Author = &Signature{}
+			}
+			if err := m.Author.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Committer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Committer == nil {
+				m.Committer = &Signature{}
+			}
+			if err := m.Committer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tree = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parents", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Parents = append(m.Parents, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TreeObject", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TreeObject == nil {
+				m.TreeObject = &Object{}
+			}
+			if err := m.TreeObject.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ParentObjects", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ParentObjects = append(m.ParentObjects, &Object{})
+			if err := m.ParentObjects[len(m.ParentObjects)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trailers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Trailers == nil {
+				m.Trailers = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGit
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGit
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthGit
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGit
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthGit
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipGit(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthGit
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Trailers[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ObjectIdentifier) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ObjectIdentifier: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ObjectIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Repo = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= (ObjectType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ref = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Traversal", wireType)
+			}
+			m.Traversal = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Traversal |= (Traversal(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Revision = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetObjectRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetObjectRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetObjectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ObjectIdentifier{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Verify", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Verify = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expand", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expand == nil {
+				m.Expand = &Expansion{}
+			}
+			if err := m.Expand.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Expansion) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Expansion: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Expansion: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TreeDepth", wireType)
+			}
+			m.TreeDepth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TreeDepth |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Blobs", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Blobs = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBlobBytes", wireType)
+			}
+			m.MaxBlobBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBlobBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parents", wireType)
+			}
+			m.Parents = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Parents |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetObjectResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetObjectResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetObjectResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Object == nil {
+				m.Object = &Object{}
+			}
+			if err := m.Object.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Verification", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Verification == nil {
+				m.Verification = &Verification{}
+			}
+			if err := m.Verification.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Truncated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Truncated = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerificationFailure) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerificationFailure: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerificationFailure: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Commit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Verification) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Verification: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Verification: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Valid", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Valid = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checked", wireType)
+			}
+			m.Checked = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Checked |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failures", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Failures = append(m.Failures, &VerificationFailure{})
+			if err := m.Failures[len(m.Failures)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Truncated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Truncated = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Precondition) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Precondition: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Precondition: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Absent", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Absent = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Upsert) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Upsert: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Upsert: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Content = append(m.Content[:0], dAtA[iNdEx:postIndex]...)
+			if m.Content == nil {
+				m.Content = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			m.Mode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Mode |= (FileMode(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gitlink", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Gitlink = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Patch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Patch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Patch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= (PatchType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Patch", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Patch = append(m.Patch[:0], dAtA[iNdEx:postIndex]...)
+			if m.Patch == nil {
+				m.Patch = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Move) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Move: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Move: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.From = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.To = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WriteCommitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WriteCommitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WriteCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Repo = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ref = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upserts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Upserts == nil {
+				m.Upserts = make(map[string][]byte)
+			}
+			var mapkey string
+			mapvalue := []byte{}
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGit
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGit
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthGit
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapbyteLen uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGit
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapbyteLen |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intMapbyteLen := int(mapbyteLen)
+					if intMapbyteLen < 0 {
+						return ErrInvalidLengthGit
+					}
+					postbytesIndex := iNdEx + intMapbyteLen
+					if postbytesIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = make([]byte, mapbyteLen)
+					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
+					iNdEx = postbytesIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipGit(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthGit
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Upserts[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deletes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Deletes = append(m.Deletes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedParent", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExpectedParent = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectAbsent", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExpectAbsent = bool(v != 0)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Preconditions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Preconditions = append(m.Preconditions, &Precondition{})
+			if err := m.Preconditions[len(m.Preconditions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rebase", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Rebase = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Author", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Author == nil {
+				m.Author = &Signature{}
+			}
+			if err := m.Author.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Committer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Committer == nil {
+				m.Committer = &Signature{}
+			}
+			if err := m.Committer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGit
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGit
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthGit
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGit
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthGit
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipGit(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthGit
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Metadata[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Moves", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Moves = append(m.Moves, &Move{})
+			if err := m.Moves[len(m.Moves)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Files", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Files = append(m.Files, &Upsert{})
+			if err := m.Files[len(m.Files)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Patches", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Patches = append(m.Patches, &Patch{})
+			if err := m.Patches[len(m.Patches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdempotencyKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IdempotencyKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowEmpty", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllowEmpty = bool(v != 0)
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TextDiff", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TextDiff = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FileChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FileChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FileChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= (ChangeType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OldHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Diff", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Diff = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WriteCommitResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WriteCommitResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WriteCommitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Commit == nil {
+				m.Commit = &Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rebased", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Rebased = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RebasedOnto", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RebasedOnto = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Deleted = append(m.Deleted, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replayed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Replayed = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unchanged", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Unchanged = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tree = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Changes = append(m.Changes, &FileChange{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	l = len(m.Msg)
-	if l > 0 {
-		n += 1 + l + sovGit(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *WriteCommitResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Commit != nil {
-		l = m.Commit.Size()
-		n += 1 + l + sovGit(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
 
-func sovGit(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozGit(x uint64) (n int) {
-	return sovGit(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *Object) Unmarshal(dAtA []byte) error {
+func (m *Repo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1944,17 +9760,17 @@ func (m *Object) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Object: wiretype end group for non-group")
+			return fmt.Errorf("proto: Repo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Object: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Repo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Blob", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -1964,29 +9780,55 @@ func (m *Object) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGit
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &Blob{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultBranch", wireType)
 			}
-			m.Obj = &Object_Blob{v}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DefaultBranch = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -1996,29 +9838,45 @@ func (m *Object) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGit
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &Tree{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Obj = &Object_Tree{v}
+			m.Head = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskSize", wireType)
+			}
+			m.DiskSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiskSize |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -2028,23 +9886,20 @@ func (m *Object) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGit
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &Commit{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Obj = &Object_Commit{v}
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2068,7 +9923,7 @@ func (m *Object) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Blob) Unmarshal(dAtA []byte) error {
+func (m *CreateRepoRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2091,15 +9946,15 @@ func (m *Blob) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Blob: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateRepoRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Blob: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateRepoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2124,13 +9979,13 @@ func (m *Blob) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hash = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitialCommit", wireType)
 			}
-			var byteLen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -2140,23 +9995,12 @@ func (m *Blob) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthGit
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Content = append(m.Content[:0], dAtA[iNdEx:postIndex]...)
-			if m.Content == nil {
-				m.Content = []byte{}
-			}
-			iNdEx = postIndex
+			m.InitialCommit = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGit(dAtA[iNdEx:])
@@ -2179,7 +10023,7 @@ func (m *Blob) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TreeEntry) Unmarshal(dAtA []byte) error {
+func (m *CreateRepoResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2202,17 +10046,17 @@ func (m *TreeEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TreeEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateRepoResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TreeEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateRepoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -2222,24 +10066,79 @@ func (m *TreeEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGit
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			if m.Repo == nil {
+				m.Repo = &Repo{}
+			}
+			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteRepoRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteRepoRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteRepoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2264,32 +10163,13 @@ func (m *TreeEntry) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hash = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
-			}
-			m.Mode = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGit
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Mode |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Entry", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Archive", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -2299,25 +10179,12 @@ func (m *TreeEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthGit
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Entry == nil {
-				m.Entry = &Object{}
-			}
-			if err := m.Entry.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.Archive = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGit(dAtA[iNdEx:])
@@ -2340,7 +10207,7 @@ func (m *TreeEntry) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Tree) Unmarshal(dAtA []byte) error {
+func (m *DeleteRepoResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2363,15 +10230,15 @@ func (m *Tree) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Tree: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteRepoResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Tree: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteRepoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ArchivePath", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2396,39 +10263,59 @@ func (m *Tree) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hash = string(dAtA[iNdEx:postIndex])
+			m.ArchivePath = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGit
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthGit
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Entries = append(m.Entries, &TreeEntry{})
-			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListReposRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
 			}
-			iNdEx = postIndex
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListReposRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListReposRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGit(dAtA[iNdEx:])
@@ -2451,7 +10338,7 @@ func (m *Tree) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Signature) Unmarshal(dAtA []byte) error {
+func (m *ListReposResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2474,17 +10361,17 @@ func (m *Signature) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Signature: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListReposResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Signature: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListReposResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Repos", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -2494,69 +10381,23 @@ func (m *Signature) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGit
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGit
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthGit
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Repos = append(m.Repos, &Repo{})
+			if err := m.Repos[len(m.Repos)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Email = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
-			}
-			m.Time = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGit
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Time |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGit(dAtA[iNdEx:])
@@ -2579,7 +10420,7 @@ func (m *Signature) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Commit) Unmarshal(dAtA []byte) error {
+func (m *GetRepoRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2602,15 +10443,15 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Commit: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetRepoRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Commit: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetRepoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2635,79 +10476,64 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hash = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Author", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGit
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthGit
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Author == nil {
-				m.Author = &Signature{}
-			}
-			if err := m.Author.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Committer", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGit
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthGit
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRepoResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Committer == nil {
-				m.Committer = &Signature{}
-			}
-			if err := m.Committer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 4:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRepoResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRepoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -2717,24 +10543,79 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGit
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			if m.Repo == nil {
+				m.Repo = &Repo{}
+			}
+			if err := m.Repo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 5:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidateTreeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidateTreeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidateTreeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tree", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2759,11 +10640,11 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tree = string(dAtA[iNdEx:postIndex])
+			m.Repo = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Parents", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2788,13 +10669,13 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Parents = append(m.Parents, string(dAtA[iNdEx:postIndex]))
+			m.Ref = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TreeObject", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
 			}
-			var msglen int
+			m.Time = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -2804,30 +10685,16 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Time |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthGit
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.TreeObject == nil {
-				m.TreeObject = &Object{}
-			}
-			if err := m.TreeObject.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ParentObjects", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Traversal", wireType)
 			}
-			var msglen int
+			m.Traversal = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -2837,23 +10704,11 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Traversal |= (Traversal(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthGit
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ParentObjects = append(m.ParentObjects, &Object{})
-			if err := m.ParentObjects[len(m.ParentObjects)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGit(dAtA[iNdEx:])
@@ -2876,7 +10731,7 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ObjectIdentifier) Unmarshal(dAtA []byte) error {
+func (m *ValidationFailure) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2899,15 +10754,15 @@ func (m *ObjectIdentifier) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ObjectIdentifier: wiretype end group for non-group")
+			return fmt.Errorf("proto: ValidationFailure: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ObjectIdentifier: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ValidationFailure: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2932,30 +10787,11 @@ func (m *ObjectIdentifier) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Repo = string(dAtA[iNdEx:postIndex])
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGit
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= (ObjectType(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2980,11 +10816,62 @@ func (m *ObjectIdentifier) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ref = string(dAtA[iNdEx:postIndex])
+			m.Reason = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidateTreeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidateTreeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidateTreeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3009,13 +10896,13 @@ func (m *ObjectIdentifier) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Path = string(dAtA[iNdEx:postIndex])
+			m.Commit = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failures", wireType)
 			}
-			m.Time = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -3025,11 +10912,23 @@ func (m *ObjectIdentifier) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Time |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Failures = append(m.Failures, &ValidationFailure{})
+			if err := m.Failures[len(m.Failures)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGit(dAtA[iNdEx:])
@@ -3052,7 +10951,7 @@ func (m *ObjectIdentifier) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetObjectRequest) Unmarshal(dAtA []byte) error {
+func (m *ObjectPath) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3075,17 +10974,17 @@ func (m *GetObjectRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetObjectRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ObjectPath: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetObjectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ObjectPath: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var msglen int
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -3095,24 +10994,39 @@ func (m *GetObjectRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Type |= (ObjectType(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthGit
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if m.Id == nil {
-				m.Id = &ObjectIdentifier{}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3136,7 +11050,7 @@ func (m *GetObjectRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetObjectResponse) Unmarshal(dAtA []byte) error {
+func (m *BatchGetObjectsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3159,15 +11073,15 @@ func (m *GetObjectResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetObjectResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchGetObjectsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetObjectResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchGetObjectsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshot", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3191,10 +11105,74 @@ func (m *GetObjectResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Object == nil {
-				m.Object = &Object{}
+			if m.Snapshot == nil {
+				m.Snapshot = &ObjectIdentifier{}
 			}
-			if err := m.Object.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Snapshot.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Objects", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Objects = append(m.Objects, &ObjectPath{})
+			if err := m.Objects[len(m.Objects)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expand", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Expand == nil {
+				m.Expand = &Expansion{}
+			}
+			if err := m.Expand.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3220,7 +11198,7 @@ func (m *GetObjectResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WriteCommitRequest) Unmarshal(dAtA []byte) error {
+func (m *BatchObject) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3243,17 +11221,17 @@ func (m *WriteCommitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WriteCommitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchObject: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WriteCommitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchObject: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Repo", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var stringLen uint64
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -3263,24 +11241,14 @@ func (m *WriteCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Type |= (ObjectType(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthGit
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Repo = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ref", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3305,11 +11273,11 @@ func (m *WriteCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ref = string(dAtA[iNdEx:postIndex])
+			m.Path = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Upserts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Object", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3333,104 +11301,18 @@ func (m *WriteCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Upserts == nil {
-				m.Upserts = make(map[string][]byte)
+			if m.Object == nil {
+				m.Object = &Object{}
 			}
-			var mapkey string
-			mapvalue := []byte{}
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowGit
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowGit
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthGit
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var mapbyteLen uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowGit
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapbyteLen |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intMapbyteLen := int(mapbyteLen)
-					if intMapbyteLen < 0 {
-						return ErrInvalidLengthGit
-					}
-					postbytesIndex := iNdEx + intMapbyteLen
-					if postbytesIndex > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = make([]byte, mapbyteLen)
-					copy(mapvalue, dAtA[iNdEx:postbytesIndex])
-					iNdEx = postbytesIndex
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipGit(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthGit
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
+			if err := m.Object.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Upserts[mapkey] = mapvalue
 			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Deletes", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			var stringLen uint64
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGit
@@ -3440,24 +11322,14 @@ func (m *WriteCommitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Code |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthGit
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Deletes = append(m.Deletes, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3482,7 +11354,7 @@ func (m *WriteCommitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Msg = string(dAtA[iNdEx:postIndex])
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3506,7 +11378,7 @@ func (m *WriteCommitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WriteCommitResponse) Unmarshal(dAtA []byte) error {
+func (m *BatchGetObjectsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3529,16 +11401,45 @@ func (m *WriteCommitResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WriteCommitResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchGetObjectsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WriteCommitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchGetObjectsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Commit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Objects", wireType)
+			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
@@ -3561,13 +11462,31 @@ func (m *WriteCommitResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Commit == nil {
-				m.Commit = &Commit{}
-			}
-			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Objects = append(m.Objects, &BatchObject{})
+			if err := m.Objects[len(m.Objects)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Truncated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Truncated = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGit(dAtA[iNdEx:])
@@ -3695,59 +11614,148 @@ var (
 	ErrIntOverflowGit   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("git.proto", fileDescriptor_git_53dae829a503651c) }
-
-var fileDescriptor_git_53dae829a503651c = []byte{
-	// 816 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xcd, 0x8e, 0xdb, 0x54,
-	0x14, 0x1e, 0xff, 0xc4, 0xa9, 0x4f, 0x66, 0xa8, 0x7b, 0xf9, 0xa9, 0x31, 0x68, 0x34, 0x18, 0x95,
-	0x46, 0x23, 0xe1, 0x88, 0x14, 0x21, 0x14, 0x58, 0xa0, 0x54, 0xa1, 0x13, 0x89, 0x36, 0xd2, 0x25,
-	0x08, 0x09, 0x56, 0xf6, 0xe4, 0x4c, 0xe2, 0x62, 0xfb, 0x1a, 0xfb, 0xa6, 0x52, 0x36, 0x48, 0xb0,
-	0xe1, 0x01, 0xd8, 0xb0, 0xe6, 0x69, 0x58, 0x22, 0xf1, 0x02, 0x68, 0xe8, 0x83, 0x54, 0xf7, 0xc7,
-	0xf9, 0x99, 0x71, 0x77, 0xe7, 0xf8, 0x7c, 0xf7, 0x3b, 0xdf, 0xfd, 0xce, 0xc9, 0x0d, 0xb8, 0xcb,
-	0x94, 0x47, 0x65, 0xc5, 0x38, 0x23, 0x9d, 0x65, 0xca, 0xcb, 0x24, 0x78, 0x7f, 0xc9, 0xd8, 0x32,
-	0xc3, 0x41, 0x5c, 0xa6, 0x83, 0xb8, 0x28, 0x18, 0x8f, 0x79, 0xca, 0x8a, 0x5a, 0x81, 0x82, 0xf7,
-	0x74, 0x55, 0x66, 0xc9, 0xfa, 0x6a, 0x80, 0x79, 0xc9, 0x37, 0xaa, 0x18, 0xfe, 0x02, 0xce, 0x2c,
-	0x79, 0x8e, 0x97, 0x9c, 0x7c, 0x00, 0x76, 0x92, 0xb1, 0xc4, 0x37, 0xcf, 0x8c, 0x7e, 0x6f, 0xd8,
-	0x8b, 0x24, 0x75, 0x34, 0xce, 0x58, 0x72, 0x71, 0x44, 0x65, 0x49, 0x40, 0x78, 0x85, 0xe8, 0x5b,
-	0x07, 0x90, 0x79, 0x85, 0x28, 0x20, 0xa2, 0x44, 0x1e, 0x82, 0x73, 0xc9, 0xf2, 0x3c, 0xe5, 0xbe,
-	0x2d, 0x41, 0x27, 0x1a, 0xf4, 0x58, 0x7e, 0xbc, 0x38, 0xa2, 0xba, 0x3c, 0xee, 0x80, 0xc5, 0x92,
-	0xe7, 0xe1, 0xa7, 0x60, 0x8b, 0x16, 0x84, 0x80, 0xbd, 0x8a, 0xeb, 0x95, 0x6f, 0x9c, 0x19, 0x7d,
-	0x97, 0xca, 0x98, 0xf8, 0xd0, 0xbd, 0x64, 0x05, 0xc7, 0x82, 0x4b, 0x51, 0xc7, 0xb4, 0x49, 0xc3,
-	0x0c, 0x5c, 0xd1, 0x75, 0x52, 0xf0, 0x6a, 0x23, 0x8e, 0x16, 0x71, 0x8e, 0xcd, 0x51, 0x11, 0x6f,
-	0xe9, 0xcc, 0x3d, 0x3a, 0x02, 0x76, 0xce, 0x16, 0x4a, 0xfd, 0x09, 0x95, 0x31, 0xf9, 0x10, 0x3a,
-	0x28, 0x48, 0x6e, 0xa8, 0x55, 0x96, 0x50, 0x55, 0x0b, 0xbf, 0x06, 0x5b, 0x74, 0x6b, 0xd5, 0x78,
-	0x0e, 0x5d, 0x01, 0x4a, 0xb1, 0xf6, 0xcd, 0x33, 0xab, 0xdf, 0x1b, 0x7a, 0x7b, 0xae, 0x48, 0x7d,
-	0xb4, 0x01, 0x84, 0x53, 0x70, 0xbf, 0x4d, 0x97, 0x45, 0xcc, 0xd7, 0x15, 0xb6, 0xaa, 0x7e, 0x0b,
-	0x3a, 0x98, 0xc7, 0x69, 0xa6, 0x65, 0xab, 0x44, 0x20, 0x79, 0x9a, 0x2b, 0xdd, 0x16, 0x95, 0x71,
-	0xf8, 0x97, 0x09, 0x8e, 0xb2, 0xb4, 0x55, 0x55, 0x1f, 0x9c, 0x78, 0xcd, 0x57, 0xac, 0xd2, 0xd3,
-	0x6c, 0x44, 0x6d, 0xdb, 0x53, 0x5d, 0x27, 0x11, 0xb8, 0x6a, 0x20, 0x1c, 0x2b, 0x3d, 0xd7, 0xdb,
-	0xe0, 0x1d, 0x44, 0xcc, 0x24, 0xc7, 0xba, 0x8e, 0x97, 0x28, 0x2d, 0x73, 0x69, 0x93, 0x4a, 0x99,
-	0x62, 0x39, 0x3a, 0x4a, 0x87, 0xdc, 0x06, 0x1f, 0xba, 0x65, 0x5c, 0x61, 0xc1, 0x6b, 0xdf, 0x39,
-	0xb3, 0x04, 0x5a, 0xa7, 0xe4, 0x63, 0x00, 0x81, 0x50, 0x46, 0xfb, 0xdd, 0x36, 0xf7, 0xf7, 0x00,
-	0xe4, 0x11, 0x9c, 0xa8, 0x93, 0x2a, 0xaf, 0xfd, 0x3b, 0xd2, 0xec, 0x1b, 0x27, 0x0e, 0x31, 0xe1,
-	0xef, 0x06, 0x78, 0x2a, 0x9e, 0x2e, 0xb0, 0xe0, 0xe9, 0x55, 0x8a, 0x95, 0x90, 0x59, 0x61, 0xc9,
-	0x1a, 0xbb, 0x44, 0x4c, 0x1e, 0x80, 0xcd, 0x37, 0x25, 0x4a, 0xb3, 0xde, 0x18, 0xde, 0x3b, 0x20,
-	0x9d, 0x6f, 0x4a, 0xa4, 0xb2, 0x4c, 0x3c, 0xb0, 0x2a, 0xbc, 0x92, 0x2e, 0xb9, 0x54, 0x84, 0x82,
-	0xac, 0x8c, 0xf9, 0x4a, 0x5b, 0x21, 0xe3, 0xed, 0xb8, 0x3a, 0x7b, 0xe3, 0xfa, 0x02, 0xbc, 0x27,
-	0xa8, 0x75, 0x51, 0xfc, 0x79, 0x8d, 0x35, 0x27, 0x0f, 0xc1, 0x4c, 0x17, 0x52, 0x46, 0x6f, 0x78,
-	0xff, 0xa0, 0xe5, 0x4e, 0x2d, 0x35, 0xd3, 0x45, 0x38, 0x82, 0x7b, 0x7b, 0x87, 0xeb, 0x92, 0x15,
-	0x35, 0x92, 0x07, 0xe0, 0x30, 0xe5, 0x9d, 0xd1, 0xe6, 0x9d, 0x2e, 0x86, 0x2f, 0x0d, 0x20, 0xdf,
-	0x57, 0x29, 0x47, 0xb5, 0x2c, 0x4d, 0xef, 0x36, 0x13, 0xf4, 0xed, 0xcc, 0xdd, 0xed, 0xbe, 0x82,
-	0xee, 0xba, 0xac, 0xb1, 0xe2, 0xb5, 0x6f, 0x49, 0xbb, 0x3f, 0xd2, 0x4d, 0x6e, 0x33, 0x46, 0xdf,
-	0x29, 0xa0, 0xde, 0x78, 0x7d, 0x4c, 0xcc, 0x7f, 0x81, 0x19, 0x72, 0xac, 0x7d, 0x5b, 0xcd, 0x5f,
-	0xa7, 0xa2, 0x5b, 0x5e, 0x2f, 0xf5, 0xb2, 0x88, 0x30, 0x18, 0xc1, 0xf1, 0x3e, 0x89, 0x40, 0xfc,
-	0x84, 0x1b, 0x2d, 0x51, 0x84, 0xe2, 0xe7, 0xf1, 0x22, 0xce, 0xd6, 0xa8, 0x5f, 0x03, 0x95, 0x8c,
-	0xcc, 0xcf, 0x8d, 0xf0, 0x4b, 0x78, 0xf3, 0x40, 0xd3, 0xce, 0x24, 0xfd, 0x18, 0x19, 0x2d, 0x8f,
-	0x51, 0xf3, 0x14, 0x9d, 0x7f, 0x06, 0xb0, 0x9b, 0x35, 0xb9, 0x03, 0xf6, 0xb3, 0xd9, 0xb3, 0x89,
-	0x77, 0x24, 0xa2, 0xf1, 0x37, 0xb3, 0xb1, 0x67, 0x88, 0x68, 0x4e, 0x27, 0x13, 0xcf, 0x24, 0x00,
-	0xce, 0xe3, 0xd9, 0xd3, 0xa7, 0xd3, 0xb9, 0x67, 0x0d, 0x7f, 0x35, 0xc1, 0x7a, 0x92, 0x72, 0x32,
-	0x05, 0xe7, 0x02, 0xe3, 0x8c, 0xaf, 0xc8, 0x3b, 0x91, 0x7a, 0x6b, 0xa3, 0xe6, 0xad, 0x8d, 0x26,
-	0xe2, 0xad, 0x0d, 0x5e, 0xf3, 0x3d, 0xbc, 0xfb, 0xdb, 0xbf, 0x2f, 0xff, 0x30, 0x5d, 0xd2, 0x1d,
-	0xac, 0x14, 0x01, 0x05, 0x77, 0x3b, 0x6b, 0xd2, 0x6c, 0xc5, 0xcd, 0xd5, 0x09, 0xfc, 0xdb, 0x05,
-	0x75, 0xe3, 0x90, 0x48, 0xc2, 0x63, 0x02, 0x83, 0x17, 0x9f, 0x0c, 0xd4, 0x0e, 0x90, 0x1f, 0xa1,
-	0xb7, 0x67, 0x0e, 0x79, 0xf7, 0xb5, 0x43, 0x0c, 0x82, 0xb6, 0x92, 0x66, 0x7e, 0x5b, 0x32, 0xdf,
-	0x0d, 0x24, 0xb3, 0x32, 0x6e, 0x64, 0x9c, 0x8f, 0xef, 0xff, 0x7d, 0x7d, 0x6a, 0xfc, 0x73, 0x7d,
-	0x6a, 0xfc, 0x77, 0x7d, 0x6a, 0xfc, 0xf9, 0xff, 0xe9, 0xd1, 0x0f, 0xea, 0x3f, 0x29, 0x71, 0xe4,
-	0x55, 0x1f, 0xbd, 0x0a, 0x00, 0x00, 0xff, 0xff, 0xd0, 0xe6, 0x07, 0x26, 0xae, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("git.proto", fileDescriptor_git_0d1a215aa3c73a40) }
+
+var fileDescriptor_git_0d1a215aa3c73a40 = []byte{
+	// 2226 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x18, 0x6b, 0x6f, 0x1b, 0x59,
+	0x35, 0xe3, 0xf7, 0x1c, 0x3b, 0xc9, 0xe4, 0xa6, 0x9b, 0x4e, 0xdd, 0x25, 0xa4, 0xb3, 0xdd, 0xb6,
+	0x0a, 0x90, 0x88, 0x14, 0xba, 0x50, 0x24, 0x20, 0x89, 0x9d, 0xc4, 0xbb, 0x79, 0x71, 0xe3, 0x74,
+	0x59, 0x10, 0xaa, 0x26, 0x9e, 0xeb, 0x78, 0xb6, 0xf6, 0x8c, 0x99, 0xb9, 0xce, 0xd6, 0x48, 0x65,
+	0x25, 0x3e, 0xf0, 0x0d, 0x21, 0x1e, 0x42, 0xf0, 0x9d, 0xdf, 0xc0, 0x6f, 0x40, 0xe2, 0x0b, 0xd2,
+	0xfe, 0x01, 0x54, 0xf8, 0x21, 0xe8, 0xdc, 0x7b, 0xe7, 0x65, 0x3b, 0xcd, 0x56, 0xfb, 0xed, 0x9e,
+	0xc7, 0x9c, 0x7b, 0xde, 0xe7, 0xdc, 0x01, 0xfd, 0xd2, 0xe5, 0x1b, 0xc3, 0xc0, 0xe7, 0x3e, 0x29,
+	0x5e, 0xba, 0x7c, 0x78, 0x51, 0x7f, 0xf7, 0xd2, 0xf7, 0x2f, 0xfb, 0x6c, 0xd3, 0x1e, 0xba, 0x9b,
+	0xb6, 0xe7, 0xf9, 0xdc, 0xe6, 0xae, 0xef, 0x85, 0x92, 0xa9, 0x7e, 0x57, 0x51, 0x05, 0x74, 0x31,
+	0xea, 0x6e, 0xb2, 0xc1, 0x90, 0x8f, 0x25, 0xd1, 0xfa, 0x35, 0x94, 0x4e, 0x2e, 0x3e, 0x65, 0x1d,
+	0x4e, 0xee, 0x41, 0xe1, 0xa2, 0xef, 0x5f, 0x98, 0xb9, 0x35, 0xed, 0x51, 0x75, 0xab, 0xba, 0x21,
+	0x44, 0x6f, 0xec, 0xf4, 0xfd, 0x8b, 0x83, 0x39, 0x2a, 0x48, 0xc8, 0xc2, 0x03, 0xc6, 0xcc, 0x7c,
+	0x86, 0xa5, 0x1d, 0x30, 0x86, 0x2c, 0x48, 0x22, 0x0f, 0xa1, 0xd4, 0xf1, 0x07, 0x03, 0x97, 0x9b,
+	0x05, 0xc1, 0x34, 0xaf, 0x98, 0x76, 0x05, 0xf2, 0x60, 0x8e, 0x2a, 0xf2, 0x4e, 0x11, 0xf2, 0xfe,
+	0xc5, 0xa7, 0xd6, 0xe7, 0x50, 0xc0, 0x2b, 0x08, 0x81, 0x42, 0xcf, 0x0e, 0x7b, 0xa6, 0xb6, 0xa6,
+	0x3d, 0xd2, 0xa9, 0x38, 0x13, 0x13, 0xca, 0x1d, 0xdf, 0xe3, 0xcc, 0xe3, 0x42, 0xa9, 0x1a, 0x8d,
+	0x40, 0xf2, 0x1e, 0x14, 0x06, 0xbe, 0x23, 0x15, 0x59, 0xd8, 0x5a, 0x54, 0x77, 0xec, 0xb9, 0x7d,
+	0x76, 0xe4, 0x3b, 0x8c, 0x0a, 0x22, 0xb9, 0x0f, 0xf3, 0xe1, 0x78, 0xd0, 0x77, 0xbd, 0x17, 0x6d,
+	0x3b, 0xb8, 0x64, 0x52, 0x23, 0x9d, 0x66, 0x91, 0xd6, 0x1f, 0x35, 0xd0, 0xd1, 0x82, 0xa6, 0xc7,
+	0x83, 0x31, 0xaa, 0xe1, 0xd9, 0x03, 0x16, 0xa9, 0x81, 0xe7, 0x58, 0xb5, 0x5c, 0x4a, 0x35, 0x92,
+	0x52, 0x60, 0x5e, 0xdd, 0xf7, 0x1e, 0x14, 0x19, 0x0a, 0x99, 0xb0, 0x5c, 0xba, 0x97, 0x4a, 0xda,
+	0xb4, 0x52, 0xc5, 0x59, 0x4a, 0xed, 0x41, 0x01, 0x75, 0x9a, 0xe9, 0x95, 0x75, 0x28, 0xa3, 0x28,
+	0x97, 0x85, 0x66, 0x6e, 0x2d, 0xff, 0xa8, 0xba, 0x65, 0xa4, 0xe2, 0x20, 0xac, 0xa0, 0x11, 0x83,
+	0xd5, 0x02, 0xfd, 0xcc, 0xbd, 0xf4, 0x6c, 0x3e, 0x0a, 0xd8, 0x4c, 0xdb, 0x6e, 0x41, 0x91, 0x0d,
+	0x6c, 0xb7, 0xaf, 0x8c, 0x93, 0x00, 0x72, 0x72, 0x77, 0x20, 0xad, 0xcb, 0x53, 0x71, 0xb6, 0xfe,
+	0x91, 0x87, 0x92, 0x0c, 0xe2, 0x4c, 0xad, 0x1e, 0x41, 0xc9, 0x1e, 0xf1, 0x9e, 0x1f, 0xa8, 0xfc,
+	0x89, 0x94, 0x8a, 0xaf, 0xa7, 0x8a, 0x4e, 0x36, 0x40, 0x97, 0x29, 0xc0, 0x59, 0x60, 0xe6, 0xaf,
+	0x61, 0x4e, 0x58, 0x30, 0x0b, 0x06, 0x2c, 0x0c, 0xed, 0x4b, 0xa6, 0x02, 0x18, 0x81, 0x84, 0xa8,
+	0x74, 0x94, 0x2e, 0x14, 0x67, 0xe4, 0x1e, 0xda, 0x01, 0xf3, 0x78, 0x68, 0x96, 0xd6, 0xf2, 0xc8,
+	0xad, 0x40, 0xf2, 0x2d, 0x00, 0xe4, 0x90, 0xe1, 0x30, 0xcb, 0xb3, 0x62, 0x94, 0x62, 0x20, 0x8f,
+	0x61, 0x5e, 0x7e, 0x29, 0xe1, 0xd0, 0xac, 0xac, 0xe5, 0xa7, 0xbf, 0xc8, 0xf2, 0x90, 0x0f, 0xa0,
+	0xc2, 0x03, 0xdb, 0xed, 0xb3, 0x20, 0x34, 0x75, 0xc1, 0x7f, 0x37, 0x93, 0xff, 0x1b, 0x6d, 0x45,
+	0x95, 0x71, 0x8a, 0x99, 0xc9, 0xbb, 0xa0, 0x87, 0x91, 0xf1, 0x26, 0x08, 0x7b, 0x12, 0x44, 0xfd,
+	0x07, 0x30, 0x9f, 0xf9, 0x90, 0x18, 0x90, 0x7f, 0xc1, 0xc6, 0x2a, 0x00, 0x78, 0xc4, 0x40, 0x5e,
+	0xd9, 0xfd, 0x11, 0x8b, 0x02, 0x29, 0x80, 0xa7, 0xb9, 0xef, 0x69, 0xd6, 0x17, 0x1a, 0x18, 0x52,
+	0xbf, 0x96, 0xc3, 0x3c, 0xee, 0x76, 0x5d, 0x16, 0xa0, 0xeb, 0x02, 0x36, 0xf4, 0xa3, 0x10, 0xe2,
+	0x99, 0xbc, 0x0f, 0x05, 0x3e, 0x1e, 0x4a, 0x09, 0x0b, 0x5b, 0x4b, 0x19, 0x43, 0xdb, 0xe3, 0x21,
+	0xa3, 0x82, 0x8c, 0x77, 0x07, 0xac, 0x2b, 0x22, 0xa7, 0x53, 0x3c, 0xa2, 0xb0, 0xa1, 0xcd, 0x7b,
+	0x2a, 0x3c, 0xe2, 0x1c, 0xa7, 0x50, 0x31, 0x49, 0x21, 0x8c, 0x3c, 0x0f, 0xec, 0x2b, 0x16, 0x84,
+	0x76, 0xdf, 0x2c, 0x89, 0x5b, 0x92, 0xdc, 0x55, 0x78, 0x9a, 0xb0, 0x90, 0x3a, 0x54, 0x02, 0x76,
+	0xe5, 0x86, 0xae, 0xef, 0x89, 0x78, 0xe9, 0x34, 0x86, 0xad, 0x57, 0x60, 0xec, 0x33, 0xe5, 0x77,
+	0xca, 0x7e, 0x39, 0x62, 0x21, 0x27, 0x0f, 0x21, 0xe7, 0x3a, 0xc2, 0xa4, 0xea, 0xd6, 0xed, 0x8c,
+	0xfa, 0x89, 0xe5, 0x34, 0xe7, 0x3a, 0x64, 0x05, 0x4a, 0x57, 0x2c, 0x70, 0xbb, 0x63, 0x61, 0x6b,
+	0x85, 0x2a, 0x08, 0x93, 0x98, 0xbd, 0x1c, 0xda, 0x9e, 0x33, 0x91, 0x97, 0x4d, 0x44, 0xe2, 0xb5,
+	0x54, 0xd1, 0xad, 0x57, 0xa0, 0xc7, 0x48, 0x0c, 0x1e, 0x26, 0x4e, 0x83, 0x0d, 0xb9, 0x2c, 0x8a,
+	0x22, 0x4d, 0x10, 0x18, 0x19, 0x6c, 0x9e, 0xa1, 0xba, 0x4b, 0x02, 0xc4, 0x82, 0xda, 0xc0, 0x7e,
+	0x89, 0xad, 0x6f, 0x67, 0xcc, 0x59, 0xa8, 0x4a, 0x2d, 0x83, 0x4b, 0xe7, 0x72, 0x41, 0x48, 0x8d,
+	0x40, 0xeb, 0x0f, 0x1a, 0x2c, 0xa5, 0xcc, 0x0f, 0x87, 0xbe, 0x17, 0x32, 0xf2, 0x3e, 0x94, 0x7c,
+	0x81, 0x51, 0x3e, 0x98, 0xc8, 0x55, 0x45, 0x24, 0x1f, 0x40, 0x4d, 0xd8, 0xeb, 0x76, 0xc4, 0x98,
+	0x50, 0x05, 0xbb, 0xac, 0x98, 0x9f, 0xa5, 0x48, 0x34, 0xc3, 0x28, 0xed, 0x1c, 0x79, 0x1d, 0x9b,
+	0x33, 0xe9, 0xa1, 0x0a, 0x4d, 0x10, 0x56, 0x13, 0x96, 0xd3, 0xdf, 0xee, 0xd9, 0x6e, 0x1f, 0xbb,
+	0xce, 0x4a, 0x3c, 0x10, 0x64, 0xae, 0x29, 0x08, 0xf1, 0x01, 0xb3, 0x43, 0x75, 0xbf, 0x4e, 0x15,
+	0x64, 0xfd, 0x59, 0x83, 0x5a, 0x5a, 0x8e, 0xca, 0x6c, 0x15, 0xd8, 0x0a, 0x95, 0x80, 0x98, 0x0d,
+	0x3d, 0xd6, 0x79, 0xc1, 0x1c, 0xf1, 0x7d, 0x9e, 0x46, 0x20, 0x79, 0x02, 0x95, 0xae, 0xbc, 0x1b,
+	0xbd, 0x8a, 0x35, 0x58, 0x9f, 0x61, 0x9a, 0x52, 0x8f, 0xc6, 0xbc, 0x59, 0xeb, 0x0a, 0x93, 0xd6,
+	0x1d, 0x43, 0xed, 0x34, 0x60, 0x1d, 0xdf, 0x73, 0x5c, 0xa1, 0x55, 0x94, 0xf3, 0x5a, 0x36, 0xe7,
+	0xa7, 0x06, 0xc5, 0x0a, 0x94, 0xec, 0x8b, 0x10, 0x47, 0x98, 0x74, 0x98, 0x82, 0xac, 0x11, 0x94,
+	0xce, 0x87, 0x21, 0x0b, 0xf8, 0x4c, 0x49, 0x5f, 0x71, 0xf2, 0x99, 0x50, 0xbe, 0x74, 0x39, 0xce,
+	0x93, 0xa8, 0x65, 0x2a, 0xd0, 0xfa, 0x18, 0x8a, 0xa7, 0x36, 0xef, 0xf4, 0x66, 0xde, 0x7a, 0x3f,
+	0xd3, 0x00, 0xa2, 0xe4, 0x17, 0xfc, 0xa9, 0xfa, 0xbf, 0x05, 0xc5, 0x21, 0xa2, 0x84, 0x0a, 0x35,
+	0x2a, 0x01, 0x6b, 0x1d, 0x0a, 0x47, 0xfe, 0x95, 0xe8, 0xc9, 0xdd, 0xc0, 0x1f, 0x44, 0x72, 0xf1,
+	0x4c, 0x16, 0x20, 0xc7, 0x7d, 0xe5, 0x95, 0x1c, 0xf7, 0xad, 0x7f, 0x95, 0x80, 0x7c, 0x1c, 0xb8,
+	0x9c, 0xc9, 0xa6, 0x18, 0x95, 0xef, 0xac, 0x9e, 0xa4, 0x9a, 0x4d, 0x2e, 0x69, 0x36, 0x3f, 0x86,
+	0xf2, 0x48, 0x38, 0x2e, 0x8a, 0xee, 0x03, 0xa5, 0xe7, 0xb4, 0xc4, 0x0d, 0xe9, 0x61, 0xd5, 0x6c,
+	0xa3, 0xcf, 0xd0, 0x3b, 0x0e, 0xeb, 0x33, 0xac, 0xba, 0x82, 0x1c, 0x11, 0x0a, 0xc4, 0xdb, 0x06,
+	0xe1, 0xa5, 0x9a, 0x27, 0x78, 0x24, 0x0f, 0x60, 0x81, 0xbd, 0x1c, 0xb2, 0x0e, 0x67, 0xce, 0xa9,
+	0xa8, 0x3d, 0xd1, 0xb7, 0x74, 0x3a, 0x81, 0xc5, 0x72, 0x96, 0x98, 0x6d, 0x19, 0xec, 0xb2, 0x08,
+	0x76, 0x06, 0x47, 0xbe, 0x0f, 0xf3, 0xc3, 0x54, 0x0a, 0x45, 0x13, 0x25, 0x2a, 0xbc, 0x74, 0x7a,
+	0xd1, 0x2c, 0xa7, 0x2c, 0x96, 0x0b, 0x3b, 0x64, 0xa6, 0x2e, 0xb3, 0x48, 0x42, 0xa9, 0xa9, 0x0b,
+	0x6f, 0x33, 0x75, 0xab, 0x37, 0x4f, 0xdd, 0x5d, 0xa8, 0x0c, 0x18, 0xb7, 0x1d, 0x9b, 0xdb, 0x66,
+	0x4d, 0xe8, 0xf9, 0xf0, 0x7a, 0x3f, 0x1f, 0x29, 0x4e, 0x35, 0xd5, 0xa2, 0x0f, 0xc9, 0x3d, 0x28,
+	0x0e, 0xfc, 0x2b, 0x16, 0x9a, 0xf3, 0x6b, 0xf9, 0xd4, 0xc2, 0x88, 0x89, 0x42, 0x25, 0x05, 0x2d,
+	0x73, 0x82, 0x31, 0x1d, 0x79, 0xe6, 0x82, 0xb4, 0x4c, 0x42, 0xb8, 0x4c, 0x75, 0xdd, 0x3e, 0x0b,
+	0xcd, 0xc5, 0xcc, 0xd8, 0x95, 0x11, 0xa5, 0x92, 0x46, 0x1e, 0x60, 0x83, 0xe4, 0x9d, 0x1e, 0x0b,
+	0x4d, 0x43, 0xb0, 0xd5, 0xd2, 0x39, 0x4b, 0x23, 0x22, 0x46, 0xd1, 0x75, 0xd8, 0x60, 0xe8, 0x73,
+	0xe6, 0x75, 0xc6, 0x1f, 0xb1, 0xb1, 0xb9, 0x24, 0xa3, 0x98, 0xc5, 0x92, 0x55, 0x00, 0xbb, 0xdf,
+	0xf7, 0x3f, 0x6b, 0xe2, 0x82, 0x6c, 0x12, 0xa1, 0x50, 0x0a, 0x83, 0x03, 0x89, 0xb3, 0x97, 0xbc,
+	0xe1, 0x76, 0xbb, 0xe6, 0xb2, 0xa0, 0xc6, 0x70, 0xfd, 0x29, 0xd4, 0xd2, 0xe9, 0x76, 0xd3, 0x88,
+	0xae, 0xa5, 0x46, 0x34, 0xce, 0xf7, 0x8c, 0x0b, 0xdf, 0x6a, 0xbe, 0xff, 0x5e, 0x03, 0xc0, 0xfa,
+	0xdf, 0xed, 0xd9, 0x9e, 0x5c, 0x8a, 0xa6, 0x0a, 0x7b, 0xf6, 0x64, 0x97, 0x1f, 0xa4, 0x2a, 0xdb,
+	0x84, 0xb2, 0xdf, 0x77, 0x0e, 0xec, 0x50, 0xd6, 0xb6, 0x4e, 0x23, 0x10, 0x29, 0x1e, 0xfb, 0x4c,
+	0x50, 0x54, 0x43, 0x51, 0x20, 0x5e, 0xe7, 0xa0, 0x3b, 0xd4, 0x0e, 0x86, 0x67, 0xeb, 0x77, 0x39,
+	0x58, 0xce, 0x64, 0x49, 0x32, 0x9f, 0x52, 0xa3, 0x60, 0xf2, 0x6d, 0x10, 0x4f, 0x06, 0x13, 0xca,
+	0x32, 0xbd, 0x1d, 0x35, 0x32, 0x23, 0x90, 0xac, 0x41, 0x55, 0x1d, 0x4f, 0x3c, 0xee, 0x2b, 0x25,
+	0xd3, 0xa8, 0xa4, 0xb6, 0x9d, 0x6c, 0x6d, 0x3b, 0x72, 0x99, 0x18, 0xf6, 0xed, 0x31, 0x73, 0x84,
+	0xb2, 0x15, 0x1a, 0xc3, 0xd8, 0xfa, 0x47, 0x5e, 0x47, 0xb8, 0xc3, 0x11, 0x05, 0x5e, 0xa1, 0x09,
+	0x22, 0x5e, 0x33, 0xcb, 0xa9, 0x35, 0xf3, 0x1b, 0x50, 0x96, 0xe4, 0xa8, 0x8a, 0x97, 0x52, 0x9d,
+	0x58, 0x3a, 0x96, 0x46, 0x1c, 0xd6, 0x6f, 0x35, 0x28, 0x50, 0xec, 0x66, 0xb3, 0x36, 0xf0, 0xfb,
+	0x30, 0xef, 0xb0, 0xae, 0x3d, 0xea, 0xf3, 0x9d, 0xc0, 0xf6, 0x3a, 0xd1, 0xf4, 0xc8, 0x22, 0xc5,
+	0x68, 0x61, 0xb6, 0xa3, 0x4c, 0x16, 0x67, 0xb4, 0xc8, 0x71, 0xc3, 0x17, 0x67, 0xee, 0xaf, 0xe4,
+	0x66, 0x9c, 0xa7, 0x31, 0x2c, 0xf6, 0xfa, 0x20, 0xf0, 0x03, 0x15, 0x17, 0x09, 0x58, 0x47, 0xb0,
+	0xb4, 0x1b, 0x30, 0x9b, 0x33, 0xd4, 0x26, 0xd5, 0x76, 0x67, 0x29, 0xe5, 0x7a, 0x2e, 0x77, 0xed,
+	0xbe, 0x8c, 0x8d, 0x0a, 0x44, 0x16, 0x69, 0x7d, 0x17, 0x48, 0x5a, 0x9c, 0x8a, 0xf2, 0xd7, 0x53,
+	0x6d, 0x3c, 0xa9, 0x79, 0xc1, 0x22, 0x08, 0xd6, 0x36, 0x2c, 0x35, 0x44, 0x50, 0x6e, 0xd2, 0xc2,
+	0x84, 0xb2, 0x1d, 0x74, 0x7a, 0xee, 0x15, 0x8b, 0x12, 0x41, 0x81, 0xd6, 0x13, 0x20, 0x69, 0x11,
+	0xea, 0xe6, 0x35, 0xa8, 0x2a, 0x86, 0xd3, 0xa4, 0x02, 0xd2, 0x28, 0x8b, 0x80, 0x71, 0xe8, 0x86,
+	0x1c, 0xbf, 0x0a, 0xd5, 0xcd, 0xd6, 0x13, 0x58, 0x4a, 0xe1, 0x94, 0xa8, 0x7b, 0x50, 0x44, 0x5d,
+	0x43, 0x53, 0xcb, 0x74, 0x2e, 0x71, 0x9d, 0xa4, 0x58, 0xf7, 0x61, 0x61, 0x9f, 0xf1, 0x1b, 0x6c,
+	0xb0, 0xb6, 0x60, 0x31, 0xe6, 0xfa, 0xb2, 0x0e, 0xfa, 0x1c, 0x96, 0x9f, 0xe1, 0x92, 0x63, 0x73,
+	0x86, 0x6f, 0xba, 0xb7, 0x9b, 0x8f, 0x33, 0xde, 0x6e, 0xd9, 0xc5, 0xbb, 0x70, 0xe3, 0xe2, 0x6d,
+	0xfd, 0x08, 0x96, 0x94, 0x02, 0xa9, 0x45, 0x6e, 0x56, 0x63, 0xb9, 0x6e, 0x89, 0x73, 0xe0, 0x56,
+	0xd6, 0x02, 0x65, 0xfa, 0x75, 0xcb, 0xe0, 0x77, 0x52, 0x3b, 0x9b, 0x7c, 0xd4, 0x9a, 0xd1, 0xce,
+	0x36, 0xa9, 0x47, 0xb2, 0xb1, 0x59, 0xfb, 0x00, 0x72, 0xb5, 0x3d, 0x4d, 0x37, 0x39, 0xed, 0xcd,
+	0xcf, 0x97, 0xc8, 0x8c, 0x5c, 0x62, 0x86, 0xf5, 0x77, 0x0d, 0x56, 0x76, 0x70, 0x56, 0xc4, 0x3b,
+	0x75, 0x94, 0x1d, 0xe4, 0x31, 0x54, 0x42, 0xcf, 0x1e, 0x86, 0x3d, 0x9f, 0xdf, 0xf4, 0xb2, 0x88,
+	0x19, 0xb1, 0x3b, 0xc8, 0x5d, 0x3b, 0xb2, 0x26, 0xab, 0x0d, 0xaa, 0x4b, 0x23, 0x8e, 0xb7, 0x78,
+	0x74, 0xfc, 0x4d, 0x83, 0xaa, 0x50, 0x53, 0x8a, 0xf9, 0x0a, 0x16, 0xa7, 0x9e, 0x0a, 0xf9, 0x37,
+	0x3d, 0x15, 0x08, 0x14, 0x3a, 0xb8, 0x6d, 0xca, 0xe7, 0x87, 0x38, 0x5f, 0xd3, 0x5a, 0x5e, 0xc1,
+	0xed, 0x29, 0x0f, 0xde, 0x10, 0xf4, 0x6f, 0x4e, 0x7a, 0x89, 0x44, 0xff, 0x9c, 0x12, 0x1b, 0x13,
+	0x37, 0xbd, 0xf1, 0xf1, 0xb1, 0xbe, 0x0d, 0x95, 0x68, 0x07, 0x26, 0x55, 0x28, 0xd3, 0xe6, 0xfe,
+	0xf9, 0xe1, 0x36, 0x35, 0xe6, 0xc8, 0x02, 0x40, 0xf3, 0xa7, 0xcd, 0xdd, 0xf3, 0xf6, 0xf6, 0xce,
+	0x61, 0xd3, 0xd0, 0x90, 0x78, 0xf6, 0xc9, 0xd1, 0x61, 0xeb, 0xf8, 0x23, 0x23, 0x87, 0xc0, 0x7e,
+	0xab, 0x2d, 0x80, 0xfc, 0xfa, 0x13, 0x80, 0xc4, 0x75, 0xa4, 0x02, 0x85, 0xe3, 0x93, 0xe3, 0xa6,
+	0x31, 0x87, 0xa7, 0x9d, 0xc3, 0x93, 0x1d, 0x43, 0xc3, 0x53, 0x9b, 0x36, 0x9b, 0x46, 0x8e, 0x00,
+	0x94, 0x76, 0x4f, 0x8e, 0x8e, 0x5a, 0x6d, 0x23, 0xbf, 0xbe, 0x89, 0xff, 0x8f, 0xa2, 0x27, 0xab,
+	0x01, 0xb5, 0xbd, 0x16, 0x3d, 0x6b, 0x3f, 0x3f, 0xdd, 0xa6, 0xcd, 0xe3, 0xb6, 0x31, 0x27, 0x30,
+	0xe7, 0x87, 0x87, 0xcf, 0x0f, 0x5a, 0x67, 0xed, 0x13, 0xfa, 0x89, 0xa1, 0xad, 0xff, 0x10, 0xf4,
+	0x78, 0xa7, 0x26, 0x8b, 0x50, 0x3d, 0x6a, 0xd2, 0xfd, 0xe6, 0xf3, 0xd3, 0xed, 0xf6, 0xee, 0x81,
+	0x54, 0xf8, 0xc3, 0xb3, 0x93, 0x63, 0x05, 0x6b, 0xf8, 0xfd, 0xf9, 0x71, 0x6b, 0xaf, 0xd5, 0x6c,
+	0x3c, 0x6f, 0xb4, 0xf6, 0xf6, 0x8c, 0xdc, 0xfa, 0x16, 0x40, 0x32, 0xba, 0x89, 0x0e, 0xc5, 0xed,
+	0x46, 0xa3, 0xd9, 0x30, 0xe6, 0x48, 0x0d, 0x2a, 0x47, 0x27, 0x0d, 0xc1, 0x2b, 0x2d, 0x6d, 0x34,
+	0x0f, 0x9b, 0xed, 0x66, 0xc3, 0xc8, 0x6d, 0xfd, 0xa5, 0x04, 0xf9, 0x7d, 0x97, 0x93, 0x16, 0x94,
+	0x0e, 0x98, 0xdd, 0xc7, 0xd2, 0xdd, 0x90, 0xbf, 0x05, 0x37, 0xa2, 0xdf, 0x82, 0x1b, 0x62, 0xc7,
+	0xa9, 0x5f, 0x83, 0xb7, 0x16, 0x7f, 0xf3, 0xc5, 0xff, 0xfe, 0x94, 0xd3, 0x49, 0x79, 0xb3, 0x27,
+	0x05, 0x50, 0xd0, 0xe3, 0x60, 0x93, 0xa8, 0x28, 0x26, 0xdf, 0xe4, 0x75, 0x73, 0x9a, 0x20, 0xd3,
+	0xc2, 0x22, 0x42, 0x60, 0x8d, 0xc0, 0xe6, 0xd5, 0xb7, 0x37, 0x55, 0xbe, 0xfd, 0x1c, 0xaa, 0xa9,
+	0xc5, 0x81, 0xdc, 0xb9, 0x76, 0xe5, 0xac, 0xd7, 0x67, 0x91, 0x94, 0xe4, 0x77, 0x84, 0xe4, 0xc5,
+	0xba, 0x90, 0x2c, 0x93, 0xed, 0xa9, 0xb6, 0x4e, 0x9e, 0x01, 0x24, 0xe3, 0x8a, 0x44, 0x8a, 0x4d,
+	0x0d, 0xc4, 0xfa, 0x9d, 0x19, 0x14, 0x25, 0x79, 0x59, 0x48, 0x9e, 0xb7, 0x2a, 0x28, 0x19, 0x1b,
+	0x30, 0xca, 0x3d, 0x03, 0x48, 0x86, 0x51, 0x2c, 0x77, 0x6a, 0xc4, 0xd5, 0xef, 0xcc, 0xa0, 0x28,
+	0xb9, 0x86, 0x90, 0x0b, 0xeb, 0xb1, 0x5c, 0xf2, 0x13, 0xd0, 0xe3, 0xa9, 0x14, 0x7b, 0x77, 0x72,
+	0x76, 0xd5, 0xcd, 0x69, 0x82, 0x92, 0xb8, 0x24, 0x24, 0x56, 0x89, 0x1e, 0x49, 0x0c, 0xc9, 0x87,
+	0x50, 0x56, 0xa3, 0x88, 0xbc, 0x93, 0x44, 0x25, 0xad, 0xe1, 0xca, 0x24, 0x3a, 0xab, 0x1e, 0x49,
+	0xd4, 0xfb, 0x05, 0xd4, 0xd2, 0x0d, 0x9e, 0xd4, 0xb3, 0xed, 0x3a, 0x3d, 0xb7, 0xea, 0x77, 0x67,
+	0xd2, 0x94, 0xe8, 0x5b, 0x42, 0xf4, 0x02, 0xa9, 0xa1, 0xe8, 0x2b, 0xc5, 0x41, 0x7a, 0xb0, 0x38,
+	0xd1, 0x4d, 0xc8, 0xd7, 0xd2, 0xcd, 0x61, 0xaa, 0x4f, 0xd7, 0x57, 0xaf, 0x23, 0xab, 0x7b, 0x56,
+	0xc4, 0x3d, 0x86, 0x55, 0x4d, 0xb2, 0x2d, 0x7c, 0xaa, 0xad, 0xef, 0xdc, 0xfe, 0xe7, 0xeb, 0x55,
+	0xed, 0xdf, 0xaf, 0x57, 0xb5, 0xff, 0xbc, 0x5e, 0xd5, 0xfe, 0xfa, 0xdf, 0xd5, 0xb9, 0x9f, 0xc9,
+	0x7f, 0xea, 0x17, 0x25, 0x91, 0xff, 0x8f, 0xff, 0x3f, 0x00, 0x59, 0xe6, 0x96, 0x00, 0x6e, 0x17,
+	0x00, 0x00,
 }
@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	ep "github.com/fiibbb/gitdb/extended_plumbing"
+	"github.com/fiibbb/gitdb/gitpb"
+	"github.com/fiibbb/gitdb/validation"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"strings"
+	"sync"
+	"time"
+)
+
+// batcher coalesces concurrent writes to a repo into group commits. Writes to
+// a ref queue up for `window`, then every queued write that does not overlap
+// an earlier one is applied in a single commit and all of them get that commit
+// back. Overlapping writes are left for the next group.
+type batcher struct {
+	g      *GitHandler
+	repo   string
+	window time.Duration
+
+	sync.Mutex
+	queues map[string][]*queuedWrite // by ref
+}
+
+type queuedWrite struct {
+	*write
+	done chan *writeResult
+}
+
+type writeResult struct {
+	resp *gitpb.WriteCommitResponse
+	err  error
+}
+
+// batcher returns the batcher of `repo`, or nil if the repo does not batch.
+func (g *GitHandler) batcher(repo string) *batcher {
+	window := g.cfg.BatchWindows[repo]
+	if window <= 0 {
+		return nil
+	}
+	b, _ := g.batchers.LoadOrStore(repo, &batcher{
+		g:      g,
+		repo:   repo,
+		window: window,
+		queues: map[string][]*queuedWrite{},
+	})
+	return b.(*batcher)
+}
+
+// submit queues `w` and waits for the group commit it ends up in. If `ctx` is
+// done first the write may still be committed.
+func (b *batcher) submit(ctx context.Context, w *write) (*gitpb.WriteCommitResponse, error) {
+	q := &queuedWrite{write: w, done: make(chan *writeResult, 1)}
+	b.enqueue(w.ref, []*queuedWrite{q}, false)
+	select {
+	case res := <-q.done:
+		return res.resp, res.err
+	case <-ctx.Done():
+		return nil, errors.WithStack(ctx.Err())
+	}
+}
+
+// enqueue adds writes to the back, or the `front`, of the queue of `ref`,
+// scheduling a flush if the queue was empty.
+func (b *batcher) enqueue(ref string, qs []*queuedWrite, front bool) {
+	b.Lock()
+	defer b.Unlock()
+	if len(b.queues[ref]) == 0 {
+		time.AfterFunc(b.window, func() { b.flush(ref) })
+	}
+	if front {
+		b.queues[ref] = append(qs, b.queues[ref]...)
+	} else {
+		b.queues[ref] = append(b.queues[ref], qs...)
+	}
+}
+
+func (b *batcher) flush(ref string) {
+	b.Lock()
+	queue := b.queues[ref]
+	delete(b.queues, ref)
+	b.Unlock()
+
+	group, deferred := splitGroup(queue)
+	if err := b.g.exclusive(b.repo, func(r *repository) error {
+		b.commitGroup(r, ref, group)
+		return nil
+	}); err != nil {
+		for _, q := range group {
+			q.done <- &writeResult{err: err}
+		}
+	}
+	if len(deferred) > 0 {
+		// Deferred writes go ahead of anything queued during the flush.
+		b.enqueue(ref, deferred, true)
+	}
+}
+
+// splitGroup picks the writes of `queue` that do not overlap any earlier write
+// in it. The rest are deferred, in order, and so are retries of a write in the
+// group, which then find its commit.
+func splitGroup(queue []*queuedWrite) ([]*queuedWrite, []*queuedWrite) {
+	var group, deferred []*queuedWrite
+	var claimed []string
+	keys := map[string]bool{}
+	for _, q := range queue {
+		claims, err := cleanPaths(q.claims())
+		if err != nil {
+			q.done <- &writeResult{err: err}
+			continue
+		}
+		overlapping, _ := ep.OverlappingPaths(claims, claimed)
+		if len(overlapping) > 0 || overlapsAny(claims, deferred) || keys[q.IdempotencyKey] {
+			deferred = append(deferred, q)
+			continue
+		}
+		if q.IdempotencyKey != "" {
+			keys[q.IdempotencyKey] = true
+		}
+		claimed = append(claimed, claims...)
+		group = append(group, q)
+	}
+	return group, deferred
+}
+
+func cleanPaths(paths []string) ([]string, error) {
+	var cleaned []string
+	for _, p := range paths {
+		c, err := ep.CleanPath(p)
+		if err != nil {
+			return nil, err
+		}
+		cleaned = append(cleaned, c)
+	}
+	return cleaned, nil
+}
+
+// overlapsAny tells whether `claims` overlap any of the writes in `qs`, which
+// keeps a write from overtaking an earlier deferred write on the same paths.
+func overlapsAny(claims []string, qs []*queuedWrite) bool {
+	for _, q := range qs {
+		overlapping, _ := ep.OverlappingPaths(q.claims(), claims)
+		if len(overlapping) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// commitGroup applies `group` in a single commit and hands every write the
+// result. If the writes cannot be merged they are committed one by one, so an
+// invalid write only fails itself. It must be called with the repo locked
+// exclusively.
+func (b *batcher) commitGroup(r *repository, ref string, group []*queuedWrite) {
+	if len(group) == 0 {
+		return
+	}
+	head, tree, err := headTree(r.Repository, ref)
+	if err != nil {
+		for _, q := range group {
+			q.done <- &writeResult{err: err}
+		}
+		return
+	}
+	var parent plumbing.Hash
+	var parents []plumbing.Hash
+	if head != nil {
+		parent = head.Hash
+		parents = []plumbing.Hash{head.Hash}
+	}
+
+	var accepted []*queuedWrite
+	for _, q := range group {
+		if resp, err := b.g.replay(r, q.write); resp != nil || err != nil {
+			q.done <- &writeResult{resp: resp, err: err}
+			continue
+		}
+		if err := ep.CheckPreconditions(tree, q.preconditions); err != nil {
+			q.done <- &writeResult{err: err}
+			continue
+		}
+		accepted = append(accepted, q)
+	}
+	if len(accepted) <= 1 {
+		b.commitEach(r, accepted)
+		return
+	}
+
+	changes := &ep.Changes{}
+	for _, q := range accepted {
+		c := q.changes()
+		changes.Upserts = append(changes.Upserts, c.Upserts...)
+		changes.Deletes = append(changes.Deletes, c.Deletes...)
+		changes.Moves = append(changes.Moves, c.Moves...)
+		changes.Patches = append(changes.Patches, c.Patches...)
+	}
+	newTree, deleted, err := ep.MakeTree(r.Storer, tree, changes)
+	if err == nil && (head == nil || newTree.Hash != head.TreeHash) {
+		err = b.validate(ref, tree, newTree)
+	}
+	if err != nil || (head != nil && newTree.Hash == head.TreeHash) {
+		// Let every write fail or find out it changes nothing on its own.
+		b.commitEach(r, accepted)
+		return
+	}
+	sig := b.g.signature(time.Now())
+	commit, err := ep.CreateCommit(r.Storer, newTree.Hash, parents, sig, sig, groupMessage(accepted), b.g.signKey)
+	if err == nil {
+		err = ep.UpdateRef(r.Storer, ref, commit.Hash, parent)
+	}
+	if err != nil {
+		b.g.logger.Info("Group commit failed, committing writes one by one", zap.String("repo", b.repo), zap.String("ref", ref), zap.Error(err))
+		b.commitEach(r, accepted)
+		return
+	}
+	b.g.syncIndex(r, ref)
+	converted, err := convertCommit(commit)
+	for _, q := range accepted {
+		// The deletes of the group do not overlap, so every deleted file
+		// belongs to exactly one write.
+		own, _ := ep.OverlappingPaths(q.Deletes, deleted)
+		q.done <- &writeResult{resp: &gitpb.WriteCommitResponse{Commit: converted, Deleted: own}, err: err}
+	}
+}
+
+func (b *batcher) validate(ref string, tree, newTree *object.Tree) error {
+	if err := checkProtected(tree, newTree, false); err != nil {
+		return err
+	}
+	if len(b.g.validators) == 0 {
+		return nil
+	}
+	changes, err := ep.DiffTrees(tree, newTree, false)
+	if err != nil {
+		return err
+	}
+	return b.g.validators.Validate(&validation.Write{
+		Repo:    b.repo,
+		Ref:     ref,
+		Old:     tree,
+		New:     newTree,
+		Changes: changes,
+	})
+}
+
+func (b *batcher) commitEach(r *repository, qs []*queuedWrite) {
+	for _, q := range qs {
+		resp, err := b.g.commit(r, q.write)
+		q.done <- &writeResult{resp: resp, err: err}
+	}
+}
+
+// groupMessage joins the messages of every write in a group commit, followed by
+// the metadata of all of them. Keys set by several writes become repeated
+// trailers.
+func groupMessage(qs []*queuedWrite) string {
+	msgs := []string{fmt.Sprintf("Group commit of %d writes", len(qs))}
+	var trailers []ep.Trailer
+	seen := map[ep.Trailer]bool{}
+	for _, q := range qs {
+		if msg := strings.TrimSpace(q.Msg); msg != "" {
+			msgs = append(msgs, msg)
+		}
+		for _, t := range q.trailers() {
+			if !seen[t] {
+				seen[t] = true
+				trailers = append(trailers, t)
+			}
+		}
+	}
+	return ep.AppendTrailers(strings.Join(msgs, "\n\n")+"\n", trailers)
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"context"
+	"github.com/fiibbb/gitdb/config"
+	"github.com/fiibbb/gitdb/consts"
+	"github.com/fiibbb/gitdb/gitpb"
+	"github.com/fiibbb/gitdb/registry"
+	"github.com/fiibbb/gitdb/validation"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+// newTestHandler returns a handler serving a fresh root with an initialized
+// repo `a`.
+func newTestHandler(t *testing.T, validators ...validation.Validator) *GitHandler {
+	root, err := ioutil.TempDir("", "gitdb-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(root) })
+	t.Setenv("GITDB_REPO_ROOT", root)
+	cfg, err := config.NewAppConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := zap.NewNop()
+	reg, err := registry.NewRegistry(cfg, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := NewGitHandler(cfg, reg, validators, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.CreateRepo(context.Background(), "a", true); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// testBatcher returns the batcher of repo `a` with a window long enough for
+// tests to flush by hand.
+func testBatcher(g *GitHandler) *batcher {
+	g.cfg.BatchWindows = map[string]time.Duration{"a": time.Hour}
+	return g.batcher("a")
+}
+
+func queued(t *testing.T, req *gitpb.WriteCommitRequest) *queuedWrite {
+	if req.Repo == "" {
+		req.Repo = "a"
+	}
+	w, err := newWrite(req, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &queuedWrite{write: w, done: make(chan *writeResult, 1)}
+}
+
+func upsert(p string) *gitpb.WriteCommitRequest {
+	return &gitpb.WriteCommitRequest{Upserts: map[string][]byte{p: []byte(p)}, Msg: "write " + p}
+}
+
+func result(t *testing.T, q *queuedWrite) *writeResult {
+	select {
+	case res := <-q.done:
+		return res
+	case <-time.After(10 * time.Second):
+		t.Fatalf("write of %v never finished", q.paths())
+		return nil
+	}
+}
+
+func TestSplitGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		reqs     []*gitpb.WriteCommitRequest
+		group    []int
+		deferred []int
+	}{
+		{
+			name:  "disjoint writes",
+			reqs:  []*gitpb.WriteCommitRequest{upsert("a/x"), upsert("a/y"), upsert("b")},
+			group: []int{0, 1, 2},
+		},
+		{
+			name:     "same file",
+			reqs:     []*gitpb.WriteCommitRequest{upsert("a/x"), upsert("b"), upsert("a/x")},
+			group:    []int{0, 1},
+			deferred: []int{2},
+		},
+		{
+			name:     "file under a deleted directory",
+			reqs:     []*gitpb.WriteCommitRequest{{Deletes: []string{"a"}}, upsert("a/x")},
+			group:    []int{0},
+			deferred: []int{1},
+		},
+		{
+			name:     "no overtaking a deferred write",
+			reqs:     []*gitpb.WriteCommitRequest{upsert("a"), {Deletes: []string{"a"}, Upserts: map[string][]byte{"b": nil}}, upsert("b")},
+			group:    []int{0},
+			deferred: []int{1, 2},
+		},
+		{
+			name: "precondition on a written path",
+			reqs: []*gitpb.WriteCommitRequest{
+				upsert("a"),
+				{Upserts: map[string][]byte{"b": nil}, Preconditions: []*gitpb.Precondition{{Path: "a", Absent: true}}},
+			},
+			group:    []int{0},
+			deferred: []int{1},
+		},
+		{
+			name: "retry of a write in the group",
+			reqs: []*gitpb.WriteCommitRequest{
+				{Upserts: map[string][]byte{"a": nil}, Msg: "m", IdempotencyKey: "k"},
+				{Upserts: map[string][]byte{"a": nil}, Msg: "m", IdempotencyKey: "k"},
+				upsert("b"),
+			},
+			group:    []int{0, 2},
+			deferred: []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queue []*queuedWrite
+			index := map[*queuedWrite]int{}
+			for i, req := range tt.reqs {
+				q := queued(t, req)
+				queue = append(queue, q)
+				index[q] = i
+			}
+			group, deferred := splitGroup(queue)
+			indexes := func(qs []*queuedWrite) []int {
+				var is []int
+				for _, q := range qs {
+					is = append(is, index[q])
+				}
+				return is
+			}
+			if got := indexes(group); !equalInts(got, tt.group) {
+				t.Errorf("got group %v, want %v", got, tt.group)
+			}
+			if got := indexes(deferred); !equalInts(got, tt.deferred) {
+				t.Errorf("got deferred %v, want %v", got, tt.deferred)
+			}
+		})
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBatcherFlush(t *testing.T) {
+	g := newTestHandler(t)
+	b := testBatcher(g)
+	overlapping := upsert("x")
+	overlapping.Upserts["x"] = []byte("x2")
+	qs := []*queuedWrite{queued(t, upsert("x")), queued(t, upsert("y")), queued(t, overlapping)}
+	b.enqueue(consts.RefNameMaster, qs, false)
+
+	b.flush(consts.RefNameMaster)
+	first, second := result(t, qs[0]), result(t, qs[1])
+	if first.err != nil || second.err != nil {
+		t.Fatal(first.err, second.err)
+	}
+	if first.resp.Commit.Hash != second.resp.Commit.Hash {
+		t.Errorf("disjoint writes got commits `%s` and `%s`, want one group commit", first.resp.Commit.Hash, second.resp.Commit.Hash)
+	}
+	select {
+	case res := <-qs[2].done:
+		t.Fatalf("overlapping write was committed with its group: %+v", res)
+	default:
+	}
+
+	// The overlapping write was deferred to the next flush.
+	b.flush(consts.RefNameMaster)
+	third := result(t, qs[2])
+	if third.err != nil {
+		t.Fatal(third.err)
+	}
+	if got := third.resp.Commit.Parents; len(got) != 1 || got[0] != first.resp.Commit.Hash {
+		t.Errorf("got parents %v of the deferred write, want the group commit `%s`", got, first.resp.Commit.Hash)
+	}
+}
+
+// rejectPath fails every write that changes `path`.
+type rejectPath string
+
+func (p rejectPath) Validate(w *validation.Write) ([]*validation.Failure, error) {
+	for _, c := range w.Changes {
+		if c.Path == string(p) {
+			return []*validation.Failure{{Path: c.Path, Reason: "rejected"}}, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestBatcherCommitsEachOnValidationFailure(t *testing.T) {
+	g := newTestHandler(t, rejectPath("bad"))
+	b := testBatcher(g)
+	qs := []*queuedWrite{queued(t, upsert("x")), queued(t, upsert("bad")), queued(t, upsert("y"))}
+	b.enqueue(consts.RefNameMaster, qs, false)
+	b.flush(consts.RefNameMaster)
+
+	x, bad, y := result(t, qs[0]), result(t, qs[1]), result(t, qs[2])
+	if _, ok := bad.err.(*validation.Error); !ok {
+		t.Fatalf("got error %v for the invalid write, want a validation error", bad.err)
+	}
+	if x.err != nil || y.err != nil {
+		t.Fatal(x.err, y.err)
+	}
+	if x.resp.Commit.Hash == y.resp.Commit.Hash {
+		t.Errorf("valid writes share commit `%s`, want one commit each", x.resp.Commit.Hash)
+	}
+	if got := y.resp.Commit.Parents; len(got) != 1 || got[0] != x.resp.Commit.Hash {
+		t.Errorf("got parents %v, want `%s`", got, x.resp.Commit.Hash)
+	}
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"github.com/fiibbb/gitdb/consts"
+	"github.com/fiibbb/gitdb/gitpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+)
+
+const (
+	// expansionReserve is kept out of the size budget for the rest of the
+	// response and the framing of nested messages.
+	expansionReserve = 64 * 1024
+	// expansionOverhead approximates the tag and length prefix of an inlined
+	// message.
+	expansionOverhead = 16
+)
+
+// expansion fills in the sugar fields of converted objects as far as the
+// request asks for and the size budget allows. Objects are expanded breadth
+// first so the budget goes to the levels closest to the requested object.
+type expansion struct {
+	s            storer.EncodedObjectStorer
+	treeDepth    int
+	blobs        bool
+	maxBlobBytes int64
+	parents      int
+	budget       int
+	truncated    bool
+}
+
+// newExpansion returns the expansion `opts` asks for, which may be nil, of
+// objects converted from the store `s`.
+func newExpansion(s storer.EncodedObjectStorer, opts *gitpb.Expansion) *expansion {
+	x := &expansion{
+		s:      s,
+		budget: consts.MaxGRPCMessageSize - expansionReserve,
+	}
+	if opts != nil {
+		x.treeDepth = int(opts.TreeDepth)
+		x.blobs = opts.Blobs
+		x.maxBlobBytes = opts.MaxBlobBytes
+		x.parents = int(opts.Parents)
+	}
+	return x
+}
+
+// take takes the size of `m` from the budget, or reports whether it does not
+// fit.
+func (x *expansion) take(m proto.Message) bool {
+	size := proto.Size(m) + expansionOverhead
+	if size > x.budget {
+		return false
+	}
+	x.budget -= size
+	return true
+}
+
+// charge takes the size of the expansion `m` from the budget, or records that
+// the response is truncated if it does not fit.
+func (x *expansion) charge(m proto.Message) bool {
+	if !x.take(m) {
+		x.truncated = true
+		return false
+	}
+	return true
+}
+
+// expand fills in the sugar fields of `obj` if it is a tree or a commit.
+func (x *expansion) expand(obj *gitpb.Object) error {
+	switch o := obj.Obj.(type) {
+	case *gitpb.Object_Tree:
+		t, err := object.GetTree(x.s, plumbing.NewHash(o.Tree.Hash))
+		if err != nil {
+			return errors.Wrapf(err, "hash `%s`", o.Tree.Hash)
+		}
+		return x.expandTree(t, o.Tree)
+	case *gitpb.Object_Commit:
+		c, err := object.GetCommit(x.s, plumbing.NewHash(o.Commit.Hash))
+		if err != nil {
+			return errors.Wrapf(err, "hash `%s`", o.Commit.Hash)
+		}
+		return x.expandCommit(c, o.Commit)
+	default:
+		return nil
+	}
+}
+
+// expandTree fills in the entries of `converted`, which was converted from
+// `t`, down to the requested depth.
+func (x *expansion) expandTree(t *object.Tree, converted *gitpb.Tree) error {
+	type level struct {
+		tree      *object.Tree
+		converted *gitpb.Tree
+		depth     int
+	}
+	queue := []level{{tree: t, converted: converted, depth: 1}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if x.treeDepth >= 0 && cur.depth > x.treeDepth {
+			continue
+		}
+		// convertTree keeps the order of the entries.
+		for i := range cur.tree.Entries {
+			entry := &cur.tree.Entries[i]
+			switch entry.Mode {
+			case filemode.Dir:
+				sub, err := object.GetTree(x.s, entry.Hash)
+				if err != nil {
+					return errors.Wrapf(err, "hash `%s`", entry.Hash)
+				}
+				tree, err := convertTree(sub)
+				if err != nil {
+					return err
+				}
+				if !x.charge(tree) {
+					continue
+				}
+				cur.converted.Entries[i].Entry = &gitpb.Object{Obj: &gitpb.Object_Tree{Tree: tree}}
+				queue = append(queue, level{tree: sub, converted: tree, depth: cur.depth + 1})
+			case filemode.Submodule:
+				// The commit lives in another repo.
+			default:
+				if !x.blobs {
+					continue
+				}
+				blob, err := x.blob(entry)
+				if err != nil {
+					return err
+				}
+				if blob != nil {
+					cur.converted.Entries[i].Entry = &gitpb.Object{Obj: &gitpb.Object_Blob{Blob: blob}}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// blob converts the blob of `entry` unless it exceeds the size limit or the
+// budget.
+func (x *expansion) blob(entry *object.TreeEntry) (*gitpb.Blob, error) {
+	b, err := object.GetBlob(x.s, entry.Hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "hash `%s`", entry.Hash)
+	}
+	if x.maxBlobBytes > 0 && b.Size > x.maxBlobBytes {
+		return nil, nil
+	}
+	if int(b.Size)+expansionOverhead > x.budget {
+		x.truncated = true
+		return nil, nil
+	}
+	blob, err := convertFile(x.s, entry)
+	if err != nil || !x.charge(blob) {
+		return nil, err
+	}
+	return blob, nil
+}
+
+// expandCommit fills in the root tree of `converted`, which was converted from
+// `c`, and its ancestors down to the requested number of generations. An
+// ancestor reachable along several paths is inlined each time, but its own
+// parents only once.
+func (x *expansion) expandCommit(c *object.Commit, converted *gitpb.Commit) error {
+	root, err := c.Tree()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := x.expandTree(root, converted.TreeObject.GetTree()); err != nil {
+		return err
+	}
+	type generation struct {
+		commit    *object.Commit
+		converted *gitpb.Commit
+		depth     int
+	}
+	queue := []generation{{commit: c, converted: converted, depth: 1}}
+	seen := map[string]bool{converted.Hash: true}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur.depth > x.parents {
+			continue
+		}
+		for _, hash := range cur.commit.ParentHashes {
+			parent, err := object.GetCommit(x.s, hash)
+			if err != nil {
+				return errors.Wrapf(err, "hash `%s`", hash)
+			}
+			commit, err := convertCommit(parent)
+			if err != nil {
+				return err
+			}
+			if !x.charge(commit) {
+				continue
+			}
+			cur.converted.ParentObjects = append(cur.converted.ParentObjects, &gitpb.Object{Obj: &gitpb.Object_Commit{Commit: commit}})
+			if !seen[commit.Hash] {
+				seen[commit.Hash] = true
+				queue = append(queue, generation{commit: parent, converted: commit, depth: cur.depth + 1})
+			}
+		}
+	}
+	return nil
+}
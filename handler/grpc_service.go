@@ -4,10 +4,18 @@ import (
 	"context"
 	"github.com/fiibbb/gitdb/config"
 	"github.com/fiibbb/gitdb/consts"
+	ep "github.com/fiibbb/gitdb/extended_plumbing"
 	"github.com/fiibbb/gitdb/gitpb"
+	"github.com/fiibbb/gitdb/validation"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
 )
 
 type GRPCService struct {
@@ -35,7 +43,64 @@ func grpcInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServe
 	if err != nil {
 		info.Server.(*GRPCService).logger.Info("error", zap.Error(err), zap.Any("request", req), zap.Any("response", resp))
 	}
-	return resp, err
+	return resp, grpcError(err)
+}
+
+// grpcError maps the cause of an error to a gRPC status code, attaching
+// details for errors that carry structured information.
+func grpcError(err error) error {
+	switch cause := errors.Cause(err).(type) {
+	case nil:
+		return nil
+	case *ep.PreconditionError:
+		failure := &errdetails.PreconditionFailure{}
+		for _, v := range cause.Violations {
+			failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+				Type:        "path",
+				Subject:     v.Path,
+				Description: v.String(),
+			})
+		}
+		return withDetails(status.New(codes.FailedPrecondition, err.Error()), failure)
+	case *validation.Error:
+		request := &errdetails.BadRequest{}
+		for _, f := range cause.Failures {
+			request.FieldViolations = append(request.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       f.Path,
+				Description: f.Reason,
+			})
+		}
+		return withDetails(status.New(codes.InvalidArgument, err.Error()), request)
+	case *ep.PatchError:
+		failure := &errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        "patch",
+				Subject:     cause.Path,
+				Description: cause.Reason,
+			}},
+		}
+		return withDetails(status.New(codes.FailedPrecondition, err.Error()), failure)
+	}
+	switch errors.Cause(err) {
+	case consts.ErrConflict:
+		return status.Error(codes.Aborted, err.Error())
+	case consts.ErrPermissionDenied:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case consts.ErrNotFound, object.ErrFileNotFound, object.ErrDirectoryNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case consts.ErrNYI:
+		return status.Error(codes.Unimplemented, err.Error())
+	default:
+		return err
+	}
+}
+
+func withDetails(st *status.Status, details ...proto.Message) error {
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
 }
 
 func (s *GRPCService) Health(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
@@ -47,17 +112,49 @@ func (s *GRPCService) Health(ctx context.Context, req *empty.Empty) (*empty.Empt
 }
 
 func (s *GRPCService) WriteCommit(ctx context.Context, req *gitpb.WriteCommitRequest) (*gitpb.WriteCommitResponse, error) {
-	resp, err := s.gitHandler.WriteCommit(ctx, req.Repo, req.Ref, req.Upserts, req.Deletes, req.Msg)
+	return s.gitHandler.WriteCommit(ctx, req)
+}
+
+func (s *GRPCService) GetObject(ctx context.Context, req *gitpb.GetObjectRequest) (*gitpb.GetObjectResponse, error) {
+	return s.gitHandler.GetObject(ctx, req)
+}
+
+func (s *GRPCService) ValidateTree(ctx context.Context, req *gitpb.ValidateTreeRequest) (*gitpb.ValidateTreeResponse, error) {
+	return s.gitHandler.ValidateTree(ctx, req)
+}
+
+func (s *GRPCService) BatchGetObjects(ctx context.Context, req *gitpb.BatchGetObjectsRequest) (*gitpb.BatchGetObjectsResponse, error) {
+	return s.gitHandler.BatchGetObjects(ctx, req)
+}
+
+func (s *GRPCService) CreateRepo(ctx context.Context, req *gitpb.CreateRepoRequest) (*gitpb.CreateRepoResponse, error) {
+	resp, err := s.gitHandler.CreateRepo(ctx, req.Name, req.InitialCommit)
 	if err != nil {
 		return nil, err
 	}
-	return &gitpb.WriteCommitResponse{Commit: resp}, consts.ErrNYI
+	return &gitpb.CreateRepoResponse{Repo: resp}, nil
 }
 
-func (s *GRPCService) GetObject(ctx context.Context, req *gitpb.GetObjectRequest) (*gitpb.GetObjectResponse, error) {
-	resp, err := s.gitHandler.GetObject(ctx, req.Id)
+func (s *GRPCService) DeleteRepo(ctx context.Context, req *gitpb.DeleteRepoRequest) (*gitpb.DeleteRepoResponse, error) {
+	resp, err := s.gitHandler.DeleteRepo(ctx, req.Name, req.Archive)
+	if err != nil {
+		return nil, err
+	}
+	return &gitpb.DeleteRepoResponse{ArchivePath: resp}, nil
+}
+
+func (s *GRPCService) ListRepos(ctx context.Context, req *gitpb.ListReposRequest) (*gitpb.ListReposResponse, error) {
+	resp, err := s.gitHandler.ListRepos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &gitpb.ListReposResponse{Repos: resp}, nil
+}
+
+func (s *GRPCService) GetRepo(ctx context.Context, req *gitpb.GetRepoRequest) (*gitpb.GetRepoResponse, error) {
+	resp, err := s.gitHandler.GetRepo(ctx, req.Name)
 	if err != nil {
 		return nil, err
 	}
-	return &gitpb.GetObjectResponse{Object: resp}, nil
+	return &gitpb.GetRepoResponse{Repo: resp}, nil
 }
@@ -2,13 +2,27 @@ package handler
 
 import (
 	"context"
+	"crypto/subtle"
 	"github.com/fiibbb/gitdb/config"
 	"github.com/fiibbb/gitdb/consts"
 	ep "github.com/fiibbb/gitdb/extended_plumbing"
 	"github.com/fiibbb/gitdb/gitpb"
+	"github.com/fiibbb/gitdb/registry"
+	"github.com/fiibbb/gitdb/validation"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/openpgp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 )
@@ -16,100 +30,581 @@ import (
 type repository struct {
 	sync.RWMutex
 	*git.Repository
+	name    string
+	path    string
+	deleted bool
+	// indexing is set while syncIndexes builds the indexes, which writes leave
+	// alone until then.
+	indexing bool
+	// index speeds up resolving commits by time.
+	index *ep.TimeIndex
+	// keys speeds up finding the commits of idempotency keys.
+	keys *ep.TrailerIndex
+	// verified holds the commits whose history was found validly signed, where
+	// verifying later histories stops.
+	verified sync.Map
 }
 
+func newRepository(r *git.Repository, name, path string) *repository {
+	index, keys := newIndexes(filepath.Join(path, indexDir))
+	return &repository{
+		Repository: r,
+		name:       name,
+		path:       path,
+		index:      index,
+		keys:       keys,
+	}
+}
+
+// indexDir is the directory under a repo its indexes are kept in.
+const indexDir = "gitdb"
+
+var indexNames = []string{"time-index", "idempotency-index"}
+
+// newIndexes returns the indexes kept in `dir`.
+func newIndexes(dir string) (*ep.TimeIndex, *ep.TrailerIndex) {
+	return ep.NewTimeIndex(filepath.Join(dir, indexNames[0])),
+		ep.NewTrailerIndex(filepath.Join(dir, indexNames[1]), idempotencyTrailer)
+}
+
+const (
+	// maxBatchObjects limits the number of objects read by one BatchGetObjects.
+	maxBatchObjects = 1000
+	// maxVerifiedCommits limits the number of commits one verification checks.
+	maxVerifiedCommits = 10000
+)
+
 type GitHandler struct {
-	cfg    *config.AppConfig
-	repos  sync.Map
-	logger *zap.Logger
+	cfg        *config.AppConfig
+	registry   *registry.Registry
+	validators validation.Validators
+	// signKey signs created commits, nil if signing is disabled.
+	signKey *openpgp.Entity
+	// trustedKeys are the keys signatures are verified against.
+	trustedKeys openpgp.EntityList
+	repos       sync.Map
+	failures    sync.Map
+	batchers    sync.Map
+	// lifecycle serializes creating and deleting repos.
+	lifecycle sync.Mutex
+	logger    *zap.Logger
+}
+
+func NewGitHandler(cfg *config.AppConfig, reg *registry.Registry, validators validation.Validators, logger *zap.Logger) (*GitHandler, error) {
+	g := &GitHandler{
+		cfg:        cfg,
+		registry:   reg,
+		validators: validators,
+		repos:      sync.Map{},
+		failures:   sync.Map{},
+		batchers:   sync.Map{},
+		logger:     logger,
+	}
+	if cfg.SigningKeyPath != "" {
+		key, err := ep.ReadSigningKey(cfg.SigningKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		g.signKey = key
+		g.trustedKeys = append(g.trustedKeys, key)
+	}
+	if cfg.TrustedKeysPath != "" {
+		keys, err := ep.ReadKeyRing(cfg.TrustedKeysPath)
+		if err != nil {
+			return nil, err
+		}
+		g.trustedKeys = append(g.trustedKeys, keys...)
+	}
+	if err := g.load(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// load registers every repository discovered by the registry. Repositories
+// that fail to open are recorded in `failures` instead of being skipped.
+func (g *GitHandler) load() error {
+	repos, failures, err := g.registry.Scan()
+	if err != nil {
+		return err
+	}
+	for _, repo := range repos {
+		r := newRepository(repo.Repository, repo.Name, repo.Path)
+		r.indexing = true
+		g.repos.Store(repo.Name, r)
+		go g.syncIndexes(r)
+		g.logger.Info("Loaded repo", zap.String("name", repo.Name), zap.String("path", repo.Path))
+	}
+	for _, failure := range failures {
+		g.failures.Store(failure.Name, failure)
+		g.logger.Error("Failed to load repo", zap.String("name", failure.Name), zap.String("path", failure.Path), zap.Error(failure.Err))
+	}
+	return nil
+}
+
+// syncIndexes builds missing indexes of the branches of `r` and catches
+// up stale ones, e.g. after pushes straight into the repo. It runs in the
+// background while loading since building walks whole histories. The histories
+// are walked without the repo lock, into a copy of the indexes that is only
+// installed under the lock, after catching up with the writes that went on
+// meanwhile. Until then writes leave the indexes alone, and reads walk the
+// history where the indexes are stale.
+func (g *GitHandler) syncIndexes(r *repository) {
+	staging, err := g.stageIndexes(r)
+	if staging != "" {
+		defer os.RemoveAll(staging)
+	}
+	r.Lock()
+	defer r.Unlock()
+	r.indexing = false
+	if r.deleted {
+		return
+	}
+	if err == nil {
+		err = installIndexes(staging, filepath.Join(r.path, indexDir))
+	}
+	if err != nil {
+		// Writes rebuild the indexes of the refs they move.
+		g.logger.Warn("Failed to build indexes", zap.String("repo", r.name), zap.Error(err))
+		return
+	}
+	branches, err := r.Branches()
+	if err != nil {
+		g.logger.Warn("Failed to list branches", zap.String("repo", r.name), zap.Error(err))
+		return
+	}
+	branches.ForEach(func(ref *plumbing.Reference) error {
+		g.syncIndex(r, ref.Name().String())
+		return nil
+	})
 }
 
-func NewGitHandler(cfg *config.AppConfig, logger *zap.Logger) (*GitHandler, error) {
-	return &GitHandler{
-		cfg:    cfg,
-		repos:  sync.Map{},
-		logger: logger,
-	}, nil
+// stageIndexes syncs a copy of the indexes of `r` with its branches, without
+// holding the repo lock, and returns the directory of the copy. The copy lives
+// next to the repo so that it can be moved into place, and outside of it so
+// that deleting the repo meanwhile does not leave parts of it behind.
+func (g *GitHandler) stageIndexes(r *repository) (string, error) {
+	staging, err := ioutil.TempDir(filepath.Dir(r.path), ".gitdb-index-")
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	for _, name := range indexNames {
+		if err := copyDir(filepath.Join(r.path, indexDir, name), filepath.Join(staging, name)); err != nil {
+			return staging, err
+		}
+	}
+	branches, err := r.Branches()
+	if err != nil {
+		return staging, errors.WithStack(err)
+	}
+	index, keys := newIndexes(staging)
+	branches.ForEach(func(ref *plumbing.Reference) error {
+		g.syncIndexesOf(r, index, keys, ref.Name().String())
+		return nil
+	})
+	return staging, nil
 }
 
-func (g *GitHandler) shared(name string, f func(*git.Repository) error) error {
+// installIndexes replaces the indexes in `dir` with the ones in `staging`.
+func installIndexes(staging, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, name := range indexNames {
+		if _, err := os.Stat(filepath.Join(staging, name)); os.IsNotExist(err) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, name)); err != nil {
+			return errors.WithStack(err)
+		}
+		if err := os.Rename(filepath.Join(staging, name), filepath.Join(dir, name)); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// copyDir copies the files under `src` to `dst`, if `src` exists.
+func copyDir(src, dst string) error {
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filepath.Join(dst, rel), b, 0644)
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return errors.WithStack(err)
+}
+
+// syncIndex brings the indexes of `ref` up to date after the ref moved, unless
+// syncIndexes is still building them.
+func (g *GitHandler) syncIndex(r *repository, ref string) {
+	if r.indexing {
+		return
+	}
+	g.syncIndexesOf(r, r.index, r.keys, ref)
+}
+
+// syncIndexesOf brings `index` and `keys` up to date with `ref` of `r`.
+// Failures are only logged since lookups walk the history while an index is
+// stale.
+func (g *GitHandler) syncIndexesOf(r *repository, index *ep.TimeIndex, keys *ep.TrailerIndex, ref string) {
+	if err := index.Sync(r.Repository, ref); err != nil {
+		g.logger.Warn("Failed to update time index", zap.String("repo", r.name), zap.String("ref", ref), zap.Error(err))
+	}
+	if err := keys.Sync(r.Repository, ref, time.Now().Add(-g.cfg.IdempotencyWindow)); err != nil {
+		g.logger.Warn("Failed to update idempotency index", zap.String("repo", r.name), zap.String("ref", ref), zap.Error(err))
+	}
+}
+
+func (g *GitHandler) repo(name string) (*repository, error) {
 	repoPtr, ok := g.repos.Load(name)
 	if !ok {
-		return errors.Errorf("repo not found `%s`", name)
+		return nil, errors.Errorf("repo not found `%s`", name)
 	}
 	repo, ok := repoPtr.(*repository)
 	if !ok {
 		panic("WTF")
 	}
-	repo.RLock()
-	defer repo.RUnlock()
-	return f(repo.Repository)
+	return repo, nil
 }
 
-func (g *GitHandler) exclusive(name string, f func(*git.Repository) error) error {
-	repoPtr, ok := g.repos.Load(name)
-	if !ok {
+func (g *GitHandler) shared(name string, f func(*repository) error) error {
+	repo, err := g.repo(name)
+	if err != nil {
+		return err
+	}
+	repo.RLock()
+	defer repo.RUnlock()
+	if repo.deleted {
 		return errors.Errorf("repo not found `%s`", name)
 	}
-	repo, ok := repoPtr.(*repository)
-	if !ok {
-		panic("WTF")
+	return f(repo)
+}
+
+func (g *GitHandler) exclusive(name string, f func(*repository) error) error {
+	repo, err := g.repo(name)
+	if err != nil {
+		return err
 	}
 	repo.Lock()
 	defer repo.Unlock()
-	return f(repo.Repository)
+	if repo.deleted {
+		return errors.Errorf("repo not found `%s`", name)
+	}
+	return f(repo)
 }
 
 func (g *GitHandler) Health(ctx context.Context) error {
 	return nil
 }
 
-func (g *GitHandler) WriteCommit(ctx context.Context, repo string, ref string, upserts map[string][]byte, deletes []string, msg string) (*gitpb.Commit, error) {
-	if ref == "" {
-		ref = consts.RefNameMaster
+func (g *GitHandler) WriteCommit(ctx context.Context, req *gitpb.WriteCommitRequest) (*gitpb.WriteCommitResponse, error) {
+	w, err := newWrite(req, g.isAdmin(ctx))
+	if err != nil {
+		return nil, err
 	}
-	if err := g.shared(repo, func(r *git.Repository) error {
-		return nil
+	if b := g.batcher(req.Repo); b != nil && w.batchable() {
+		return b.submit(ctx, w)
+	}
+	var resp *gitpb.WriteCommitResponse
+	if err := g.exclusive(req.Repo, func(r *repository) error {
+		resp, err = g.commit(r, w)
+		return err
 	}); err != nil {
 		return nil, err
 	}
-	return nil, consts.ErrNYI
+	return resp, nil
+}
+
+// signature is the server identity used for commits.
+func (g *GitHandler) signature(when time.Time) object.Signature {
+	return object.Signature{
+		Name:  g.cfg.CommitterName,
+		Email: g.cfg.CommitterEmail,
+		When:  when,
+	}
 }
 
-func (g *GitHandler) GetObject(ctx context.Context, id *gitpb.ObjectIdentifier) (*gitpb.Object, error) {
+// isAdmin tells whether the caller presented the admin token.
+func (g *GitHandler) isAdmin(ctx context.Context) bool {
+	if g.cfg.AdminToken == "" {
+		return false
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, token := range md.Get(consts.AdminTokenKey) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(g.cfg.AdminToken)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetObject returns the object `req` identifies, expanded as requested. If the
+// request asks to verify, the signatures of the resolved commit and all its
+// ancestors are checked as well.
+func (g *GitHandler) GetObject(ctx context.Context, req *gitpb.GetObjectRequest) (*gitpb.GetObjectResponse, error) {
+	id, verify := req.Id, req.Verify
+	if verify && len(g.trustedKeys) == 0 {
+		return nil, errors.New("cannot verify signatures, no keys are configured")
+	}
+	resp := &gitpb.GetObjectResponse{}
 	var obj *gitpb.Object
-	if err := g.shared(id.Repo, func(repo *git.Repository) error {
-		commit, err := ep.ResolveCommit(repo, id.Ref, time.Unix(id.Time, 0))
+	var verification *gitpb.Verification
+	if err := g.shared(id.Repo, func(repo *repository) error {
+		traversal, err := convertTraversal(id.Traversal)
+		if err != nil {
+			return err
+		}
+		resolved, err := resolveObject(repo, id, traversal)
 		if err != nil {
 			return err
 		}
+		var root *object.Tree
+		commit, isCommit := resolved.(*object.Commit)
+		switch o := resolved.(type) {
+		case *object.Commit:
+			if root, err = o.Tree(); err != nil {
+				return errors.WithStack(err)
+			}
+		case *object.Tree:
+			root = o
+		case *object.Blob:
+			if id.Type != gitpb.ObjectType_BLOB || id.Path != "" {
+				return errors.Errorf("revision `%s` is a blob, it can only be read as a blob without a path", id.Revision)
+			}
+			obj, err = convertFileObject(repo.Storer, &object.TreeEntry{Mode: filemode.Regular, Hash: o.Hash})
+			return err
+		default:
+			return errors.Errorf("revision `%s` is a %s", id.Revision, resolved.Type())
+		}
+		if verify {
+			if !isCommit {
+				return errors.Errorf("revision `%s` is not a commit, only commits can be verified", id.Revision)
+			}
+			if verification, err = g.verifyHistory(repo, commit); err != nil {
+				return err
+			}
+		}
+		if obj, err = readObject(repo.Storer, root, commit, id.Type, id.Path); err != nil {
+			return err
+		}
+		x := newExpansion(repo.Storer, req.Expand)
+		if !x.take(obj) {
+			// The object is returned anyway, but leaves no room to expand it.
+			x.budget = 0
+		}
+		err = x.expand(obj)
+		resp.Truncated = x.truncated
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	resp.Object = obj
+	resp.Verification = verification
+	return resp, nil
+}
+
+// readObject converts the object of type `typ` at `path` in `root`, the tree of
+// `commit`. The commit is nil if the object is read from a tree directly.
+func readObject(s storer.EncodedObjectStorer, root *object.Tree, commit *object.Commit, typ gitpb.ObjectType, path string) (*gitpb.Object, error) {
+	switch typ {
+	case gitpb.ObjectType_BLOB:
+		p, err := ep.CleanPath(path)
+		if err != nil {
+			return nil, err
+		}
+		entry, err := ep.FindEntry(root, p)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil || entry.Mode == filemode.Dir {
+			return nil, errors.Wrapf(object.ErrFileNotFound, "path `%s`", path)
+		}
+		return convertFileObject(s, entry)
+	case gitpb.ObjectType_TREE:
+		folder := root
+		if path != "" {
+			var err error
+			if folder, err = root.Tree(path); err != nil {
+				return nil, errors.Wrapf(err, "path `%s`", path)
+			}
+		}
+		return convertTreeObject(folder)
+	case gitpb.ObjectType_COMMIT:
+		if commit == nil {
+			return nil, errors.Errorf("tree `%s` is not a commit", root.Hash)
+		}
+		return convertCommitObject(commit)
+	default:
+		return nil, errors.Errorf("unrecognized object type `%v`", typ)
+	}
+}
+
+// BatchGetObjects reads every object `req` asks for from the commit its
+// snapshot identifies. The commit is resolved once and the repo stays locked
+// throughout, so all objects come from the same snapshot even if writes land
+// meanwhile. Failing to read an object fails only that object.
+func (g *GitHandler) BatchGetObjects(ctx context.Context, req *gitpb.BatchGetObjectsRequest) (*gitpb.BatchGetObjectsResponse, error) {
+	id := req.Snapshot
+	if id == nil {
+		return nil, errors.New("snapshot is required")
+	}
+	if len(req.Objects) > maxBatchObjects {
+		return nil, errors.Errorf("%d objects requested, at most %d are allowed", len(req.Objects), maxBatchObjects)
+	}
+	resp := &gitpb.BatchGetObjectsResponse{}
+	if err := g.shared(id.Repo, func(repo *repository) error {
+		traversal, err := convertTraversal(id.Traversal)
+		if err != nil {
+			return err
+		}
+		resolved, err := resolveObject(repo, id, traversal)
+		if err != nil {
+			return err
+		}
+		commit, ok := resolved.(*object.Commit)
+		if !ok {
+			return errors.Errorf("revision `%s` is a %s, not a commit", id.Revision, resolved.Type())
+		}
 		root, err := commit.Tree()
 		if err != nil {
 			return errors.WithStack(err)
 		}
-		switch id.Type {
-		case gitpb.ObjectType_BLOB:
-			file, err := root.File(id.Path)
+		resp.Commit = commit.Hash.String()
+		x := newExpansion(repo.Storer, req.Expand)
+		// Convert everything before expanding so that the budget goes to the
+		// objects themselves first.
+		for _, o := range req.Objects {
+			converted := &gitpb.BatchObject{Type: o.Type, Path: o.Path}
+			obj, err := readObject(repo.Storer, root, commit, o.Type, o.Path)
+			if err == nil && !x.take(obj) {
+				err = status.Errorf(codes.ResourceExhausted, "%s `%s` does not fit in the response", o.Type, o.Path)
+			}
 			if err != nil {
-				return errors.Wrapf(err, "path `%s`", id.Path)
+				st := status.Convert(grpcError(err))
+				converted.Code, converted.Error = int32(st.Code()), st.Message()
+			} else {
+				converted.Object = obj
 			}
-			obj, err = convertBlobObject(&file.Blob)
-			return err
-		case gitpb.ObjectType_TREE:
-			folder, err := root.Tree(id.Path)
+			resp.Objects = append(resp.Objects, converted)
+		}
+		for _, o := range resp.Objects {
+			if o.Object != nil {
+				if err := x.expand(o.Object); err != nil {
+					return err
+				}
+			}
+		}
+		resp.Truncated = x.truncated
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// resolveObject finds the commit `id` identifies by ref and time, or the object
+// its revision names.
+func resolveObject(repo *repository, id *gitpb.ObjectIdentifier, traversal ep.Traversal) (object.Object, error) {
+	if id.Revision == "" {
+		return ep.ResolveCommit(repo.Repository, id.Ref, time.Unix(id.Time, 0), traversal, repo.index)
+	}
+	if id.Ref != "" || id.Time != 0 {
+		return nil, errors.New("revision cannot be combined with ref or time")
+	}
+	return ep.ResolveRevision(repo.Repository, id.Revision, traversal, repo.index)
+}
+
+// verifyHistory checks the signature of `commit` and every commit reachable
+// from it against the trusted keys. It stops at commits whose history was
+// verified before and after maxVerifiedCommits commits.
+func (g *GitHandler) verifyHistory(r *repository, commit *object.Commit) (*gitpb.Verification, error) {
+	verification := &gitpb.Verification{Valid: true}
+	seen := map[plumbing.Hash]bool{commit.Hash: true}
+	stack := []*object.Commit{commit}
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, ok := r.verified.Load(c.Hash); ok {
+			continue
+		}
+		if verification.Checked == maxVerifiedCommits {
+			verification.Valid = false
+			verification.Truncated = true
+			break
+		}
+		verification.Checked++
+		if err := ep.VerifyCommit(r.Storer, c, g.trustedKeys); err != nil {
+			verification.Valid = false
+			verification.Failures = append(verification.Failures, &gitpb.VerificationFailure{
+				Commit: c.Hash.String(),
+				Reason: errors.Cause(err).Error(),
+			})
+		}
+		for _, hash := range c.ParentHashes {
+			if seen[hash] {
+				continue
+			}
+			seen[hash] = true
+			parent, err := r.CommitObject(hash)
 			if err != nil {
-				return errors.Wrapf(err, "path `%s`", id.Path)
+				return nil, errors.Wrapf(err, "parent of `%s`", c.Hash)
 			}
-			obj, err = convertTreeObject(folder)
+			stack = append(stack, parent)
+		}
+	}
+	if verification.Valid {
+		r.verified.Store(commit.Hash, true)
+	}
+	return verification, nil
+}
+
+// ValidateTree checks the tree of the commit `req` resolves to against the
+// schemas stored in it.
+func (g *GitHandler) ValidateTree(ctx context.Context, req *gitpb.ValidateTreeRequest) (*gitpb.ValidateTreeResponse, error) {
+	at := time.Now()
+	if req.Time != 0 {
+		at = time.Unix(req.Time, 0)
+	}
+	var resp *gitpb.ValidateTreeResponse
+	if err := g.shared(req.Repo, func(repo *repository) error {
+		traversal, err := convertTraversal(req.Traversal)
+		if err != nil {
 			return err
-		case gitpb.ObjectType_COMMIT:
-			obj, err = convertCommitObject(commit)
+		}
+		commit, err := ep.ResolveCommit(repo.Repository, req.Ref, at, traversal, repo.index)
+		if err != nil {
 			return err
-		default:
-			return errors.Errorf("unrecognized object type `%v`", id.Type)
 		}
+		root, err := commit.Tree()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		failures, err := validation.ValidateTree(root)
+		if err != nil {
+			return err
+		}
+		resp = &gitpb.ValidateTreeResponse{Commit: commit.Hash.String()}
+		for _, f := range failures {
+			resp.Failures = append(resp.Failures, &gitpb.ValidationFailure{Path: f.Path, Reason: f.Reason})
+		}
+		return nil
 	}); err != nil {
 		return nil, err
 	}
-	return obj, nil
+	return resp, nil
 }
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"context"
+	"github.com/fiibbb/gitdb/config"
+	"github.com/fiibbb/gitdb/consts"
+	ep "github.com/fiibbb/gitdb/extended_plumbing"
+	"github.com/fiibbb/gitdb/gitpb"
+	"github.com/fiibbb/gitdb/registry"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"google.golang.org/grpc/codes"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSigningKeyConfig(t *testing.T) {
+	key, err := openpgp.NewEntity("gitdb", "", "gitdb@localhost", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "key.asc")
+	f, err := os.Create(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := armor.Encode(f, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := key.SerializePrivate(w, nil); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	f.Close()
+
+	// Configured keys that do not load fail startup.
+	for env, p := range map[string]string{"GITDB_SIGNING_KEY": "missing.asc", "GITDB_TRUSTED_KEYS": "missing.asc"} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv("GITDB_REPO_ROOT", t.TempDir())
+			t.Setenv(env, filepath.Join(dir, p))
+			cfg, err := config.NewAppConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			reg, err := registry.NewRegistry(cfg, zap.NewNop())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := NewGitHandler(cfg, reg, nil, zap.NewNop()); err == nil {
+				t.Errorf("started with %s naming a missing file", env)
+			}
+		})
+	}
+
+	t.Setenv("GITDB_SIGNING_KEY", keyPath)
+	g := newTestHandler(t)
+	resp, err := g.WriteCommit(context.Background(), &gitpb.WriteCommitRequest{Repo: "a", Msg: "m", Upserts: map[string][]byte{"a": []byte("a")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := g.repo("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err := r.CommitObject(plumbing.NewHash(resp.Commit.Hash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.PGPSignature == "" {
+		t.Error("commit is not signed with the configured key")
+	}
+}
+
+func TestSyncIndexes(t *testing.T) {
+	g := newTestHandler(t)
+	admin := adminContext(g)
+	r, err := g.repo("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Writes while the indexes are built leave them alone.
+	r.indexing = true
+	resp, err := g.WriteCommit(context.Background(), &gitpb.WriteCommitRequest{Repo: "a", Msg: "m", Upserts: map[string][]byte{"a": []byte("a")}, IdempotencyKey: "k"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	head := plumbing.NewHash(resp.Commit.Hash)
+	if _, ok, _ := r.index.Lookup(consts.RefNameMaster, head, time.Now()); ok {
+		t.Fatal("write synced the indexes while they were built")
+	}
+
+	g.syncIndexes(r)
+	if _, ok, err := r.index.Lookup(consts.RefNameMaster, head, time.Now()); err != nil || !ok {
+		t.Errorf("got %v, %v from the built time index", ok, err)
+	}
+	if hash, ok, err := r.keys.Lookup(consts.RefNameMaster, head, "k", time.Now().Add(-time.Hour)); err != nil || !ok || hash != head {
+		t.Errorf("got %s, %v, %v from the built idempotency index, want %s", hash, ok, err, head)
+	}
+	if r.indexing {
+		t.Error("writes still leave the built indexes alone")
+	}
+
+	// Building the indexes of a repo deleted meanwhile leaves nothing behind.
+	r.indexing = true
+	if _, err := g.DeleteRepo(admin, "a", false); err != nil {
+		t.Fatal(err)
+	}
+	g.syncIndexes(r)
+	entries, err := ioutil.ReadDir(filepath.Dir(r.path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		t.Errorf("`%s` left behind", e.Name())
+	}
+}
+
+func TestVerifyHistory(t *testing.T) {
+	g := newTestHandler(t)
+	ctx := context.Background()
+	key, err := openpgp.NewEntity("gitdb", "", "gitdb@localhost", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := openpgp.NewEntity("other", "", "other@localhost", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.signKey = key
+	g.trustedKeys = openpgp.EntityList{key, other}
+	if _, err := g.CreateRepo(ctx, "b", false); err != nil {
+		t.Fatal(err)
+	}
+	write := func(p string) *gitpb.Commit {
+		resp, err := g.WriteCommit(ctx, &gitpb.WriteCommitRequest{Repo: "b", Msg: "m", Upserts: map[string][]byte{p: []byte(p)}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Commit
+	}
+	verify := func() *gitpb.Verification {
+		resp, err := g.GetObject(ctx, &gitpb.GetObjectRequest{
+			Id:     &gitpb.ObjectIdentifier{Repo: "b", Type: gitpb.ObjectType_COMMIT, Revision: "master"},
+			Verify: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Verification
+	}
+
+	// A commit signed by a trusted key other than the signing key, e.g.
+	// pushed straight into the repo.
+	first := write("a")
+	r, err := g.repo("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := object.Signature{Name: "other", Email: "other@localhost", When: time.Now()}
+	pushed, err := ep.CreateCommit(r.Storer, plumbing.NewHash(first.Tree), []plumbing.Hash{plumbing.NewHash(first.Hash)}, sig, sig, "pushed\n", other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ep.UpdateRef(r.Storer, "refs/heads/master", pushed.Hash, plumbing.NewHash(first.Hash)); err != nil {
+		t.Fatal(err)
+	}
+	if v := verify(); !v.Valid || v.Checked != 2 || v.Truncated {
+		t.Errorf("got %+v, want 2 valid commits", v)
+	}
+
+	// Verified histories are not checked again.
+	write("b")
+	if v := verify(); !v.Valid || v.Checked != 1 {
+		t.Errorf("got %+v, want 1 valid commit", v)
+	}
+
+	// An unsigned commit merged in fails every verification.
+	unsigned, err := ep.CreateCommit(r.Storer, pushed.TreeHash, []plumbing.Hash{pushed.Hash}, sig, sig, "unsigned\n", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := ep.HeadCommit(r.Repository, "refs/heads/master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	merge, err := ep.CreateCommit(r.Storer, head.TreeHash, []plumbing.Hash{head.Hash, unsigned.Hash}, sig, sig, "merge\n", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ep.UpdateRef(r.Storer, "refs/heads/master", merge.Hash, head.Hash); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		v := verify()
+		if v.Valid || v.Checked != 2 || len(v.Failures) != 1 || v.Failures[0].Commit != unsigned.Hash.String() {
+			t.Errorf("got %+v, want the unsigned commit to fail", v)
+		}
+	}
+}
+
+func TestBatchGetObjects(t *testing.T) {
+	g := newTestHandler(t)
+	ctx := context.Background()
+	write := func(content string) *gitpb.Commit {
+		resp, err := g.WriteCommit(ctx, &gitpb.WriteCommitRequest{Repo: "a", Msg: "m", Upserts: map[string][]byte{"f": []byte(content)}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Commit
+	}
+	objects := []*gitpb.ObjectPath{
+		{Type: gitpb.ObjectType_BLOB, Path: "f"},
+		{Type: gitpb.ObjectType_BLOB, Path: "missing"},
+		{Type: gitpb.ObjectType_TREE, Path: ""},
+	}
+	batch := func(revision string) *gitpb.BatchGetObjectsResponse {
+		resp, err := g.BatchGetObjects(ctx, &gitpb.BatchGetObjectsRequest{
+			Snapshot: &gitpb.ObjectIdentifier{Repo: "a", Revision: revision},
+			Objects:  objects,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	first := write("1")
+	second := write("2")
+	// Every object is read from the snapshot, not from where the ref moved.
+	for _, tt := range []struct {
+		revision, commit, content string
+	}{
+		{revision: first.Hash, commit: first.Hash, content: "1"},
+		{revision: "master", commit: second.Hash, content: "2"},
+	} {
+		resp := batch(tt.revision)
+		if resp.Commit != tt.commit {
+			t.Errorf("revision `%s`: got commit %s, want %s", tt.revision, resp.Commit, tt.commit)
+		}
+		if len(resp.Objects) != len(objects) {
+			t.Fatalf("revision `%s`: got %d objects, want %d", tt.revision, len(resp.Objects), len(objects))
+		}
+		if got := string(resp.Objects[0].Object.GetBlob().GetContent()); got != tt.content {
+			t.Errorf("revision `%s`: got content %q, want %q", tt.revision, got, tt.content)
+		}
+		// A missing path fails on its own.
+		if o := resp.Objects[1]; o.Object != nil || codes.Code(o.Code) != codes.NotFound {
+			t.Errorf("revision `%s`: got %+v for a missing path, want not found", tt.revision, o)
+		}
+		if o := resp.Objects[2]; o.Object.GetTree() == nil || codes.Code(o.Code) != codes.OK {
+			t.Errorf("revision `%s`: got %+v for the root tree", tt.revision, o)
+		}
+	}
+
+	tooMany := make([]*gitpb.ObjectPath, maxBatchObjects+1)
+	for i := range tooMany {
+		tooMany[i] = objects[0]
+	}
+	req := &gitpb.BatchGetObjectsRequest{Snapshot: &gitpb.ObjectIdentifier{Repo: "a", Revision: "master"}, Objects: tooMany}
+	if _, err := g.BatchGetObjects(ctx, req); err == nil {
+		t.Errorf("got no error reading %d objects", len(tooMany))
+	}
+}
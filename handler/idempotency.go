@@ -0,0 +1,69 @@
+package handler
+
+import (
+	ep "github.com/fiibbb/gitdb/extended_plumbing"
+	"github.com/fiibbb/gitdb/gitpb"
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"time"
+)
+
+// idempotencyTrailer is the trailer recording the idempotency key of a write in
+// its commit.
+const idempotencyTrailer = "Idempotency-Key"
+
+func (w *write) idempotencyTrailer() ep.Trailer {
+	return ep.Trailer{Key: idempotencyTrailer, Value: w.IdempotencyKey}
+}
+
+// replay looks for the commit of an earlier write with the idempotency key of
+// `w` among the commits of the ref within the idempotency window, in the
+// idempotency index unless it is stale. It returns nil if there is none. It
+// must be called with the repo locked.
+func (g *GitHandler) replay(r *repository, w *write) (*gitpb.WriteCommitResponse, error) {
+	if w.IdempotencyKey == "" {
+		return nil, nil
+	}
+	commit, err := ep.HeadCommit(r.Repository, w.ref)
+	if errors.Cause(err) == plumbing.ErrReferenceNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	since := time.Now().Add(-g.cfg.IdempotencyWindow)
+	hash, ok, err := r.keys.Lookup(w.ref, commit.Hash, w.IdempotencyKey, since)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if hash.IsZero() {
+			return nil, nil
+		}
+		if commit, err = r.CommitObject(hash); err != nil {
+			return nil, errors.Wrapf(err, "hash `%s`", hash)
+		}
+		return replayed(commit)
+	}
+	for commit.Committer.When.After(since) {
+		if ep.HasTrailer(commit.Message, w.idempotencyTrailer()) {
+			return replayed(commit)
+		}
+		if commit.NumParents() == 0 {
+			break
+		}
+		if commit, err = commit.Parent(0); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return nil, nil
+}
+
+func replayed(commit *object.Commit) (*gitpb.WriteCommitResponse, error) {
+	converted, err := convertCommit(commit)
+	if err != nil {
+		return nil, err
+	}
+	return &gitpb.WriteCommitResponse{Commit: converted, Replayed: true}, nil
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"context"
+	"github.com/fiibbb/gitdb/consts"
+	ep "github.com/fiibbb/gitdb/extended_plumbing"
+	"github.com/fiibbb/gitdb/gitpb"
+	"github.com/fiibbb/gitdb/registry"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+func (g *GitHandler) CreateRepo(ctx context.Context, name string, initialCommit bool) (*gitpb.Repo, error) {
+	p, err := g.registry.PathOf(name)
+	if err != nil {
+		return nil, err
+	}
+	g.lifecycle.Lock()
+	defer g.lifecycle.Unlock()
+	if _, ok := g.repos.Load(name); ok {
+		return nil, errors.Errorf("repo already exists `%s`", name)
+	}
+	if _, err := os.Stat(p); err == nil {
+		return nil, errors.Errorf("repo path already exists `%s`", p)
+	}
+	r, err := git.PlainInit(p, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "init `%s`", p)
+	}
+	if initialCommit {
+		if err := g.seed(r); err != nil {
+			os.RemoveAll(p)
+			return nil, err
+		}
+	}
+	repo := newRepository(r, name, p)
+	if initialCommit {
+		g.syncIndex(repo, consts.RefNameMaster)
+	}
+	g.repos.Store(name, repo)
+	g.logger.Info("Created repo", zap.String("name", name), zap.String("path", p))
+	return describeRepo(repo)
+}
+
+// seed points refs/heads/master at an empty commit.
+func (g *GitHandler) seed(r *git.Repository) error {
+	tree, err := ep.WriteTree(r.Storer, &object.Tree{})
+	if err != nil {
+		return err
+	}
+	sig := g.signature(time.Now())
+	commit, err := ep.CreateCommit(r.Storer, tree, nil, sig, sig, "Initial commit\n", g.signKey)
+	if err != nil {
+		return err
+	}
+	ref := plumbing.NewHashReference(consts.RefNameMaster, commit.Hash)
+	return errors.WithStack(r.Storer.SetReference(ref))
+}
+
+// DeleteRepo removes a repo from disk, or moves it under the registry archive
+// directory if `archive` is set, returning the path it was archived to. It
+// requires the admin token.
+func (g *GitHandler) DeleteRepo(ctx context.Context, name string, archive bool) (string, error) {
+	if !g.isAdmin(ctx) {
+		return "", errors.Wrapf(consts.ErrPermissionDenied, "deleting repos requires the admin token")
+	}
+	g.lifecycle.Lock()
+	defer g.lifecycle.Unlock()
+	var p string
+	var repo *repository
+	if failure, ok := g.failures.Load(name); ok {
+		p = failure.(*registry.Failure).Path
+	} else {
+		var err error
+		if repo, err = g.repo(name); err != nil {
+			return "", err
+		}
+		repo.Lock()
+		defer repo.Unlock()
+		p = repo.path
+	}
+	var archivePath string
+	if archive {
+		archivePath = g.registry.ArchivePathOf(name, time.Now())
+		if err := os.MkdirAll(filepath.Dir(archivePath), 0755); err != nil {
+			return "", errors.WithStack(err)
+		}
+		if err := os.Rename(p, archivePath); err != nil {
+			return "", errors.Wrapf(err, "archive `%s`", p)
+		}
+	} else if err := os.RemoveAll(p); err != nil {
+		return "", errors.Wrapf(err, "remove `%s`", p)
+	}
+	// Only flagged once the repo is gone from disk, so that it stays usable if
+	// removing it fails.
+	if repo != nil {
+		repo.deleted = true
+	}
+	g.repos.Delete(name)
+	g.failures.Delete(name)
+	// A recreated repo gets a batcher of its own.
+	g.batchers.Delete(name)
+	g.logger.Info("Deleted repo", zap.String("name", name), zap.String("path", p), zap.String("archive", archivePath))
+	return archivePath, nil
+}
+
+// ListRepos lists every registered repo, including the ones that failed to open.
+// Repos that cannot be described are listed with the error, like the ones that
+// failed to open.
+func (g *GitHandler) ListRepos(ctx context.Context) ([]*gitpb.Repo, error) {
+	var repos []*gitpb.Repo
+	g.repos.Range(func(key, value interface{}) bool {
+		repo := value.(*repository)
+		repo.RLock()
+		defer repo.RUnlock()
+		if repo.deleted {
+			return true
+		}
+		desc, err := describeRepo(repo)
+		if err != nil {
+			g.logger.Error("Failed to describe repo", zap.String("name", repo.name), zap.String("path", repo.path), zap.Error(err))
+			desc = &gitpb.Repo{Name: repo.name, Error: err.Error()}
+		}
+		repos = append(repos, desc)
+		return true
+	})
+	g.failures.Range(func(key, value interface{}) bool {
+		repos = append(repos, convertFailure(value.(*registry.Failure)))
+		return true
+	})
+	sort.Slice(repos, func(i, j int) bool {
+		return repos[i].Name < repos[j].Name
+	})
+	return repos, nil
+}
+
+func (g *GitHandler) GetRepo(ctx context.Context, name string) (*gitpb.Repo, error) {
+	if failure, ok := g.failures.Load(name); ok {
+		return convertFailure(failure.(*registry.Failure)), nil
+	}
+	repo, err := g.repo(name)
+	if err != nil {
+		return nil, err
+	}
+	repo.RLock()
+	defer repo.RUnlock()
+	if repo.deleted {
+		return nil, errors.Errorf("repo not found `%s`", name)
+	}
+	return describeRepo(repo)
+}
+
+// describeRepo must be called with the repo locked.
+func describeRepo(repo *repository) (*gitpb.Repo, error) {
+	desc := &gitpb.Repo{Name: repo.name}
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return nil, errors.Wrapf(err, "repo `%s`", repo.name)
+	}
+	if head.Type() == plumbing.SymbolicReference {
+		desc.DefaultBranch = head.Target().String()
+	}
+	resolved, err := repo.Reference(plumbing.HEAD, true)
+	switch err {
+	case nil:
+		desc.Head = resolved.Hash().String()
+	case plumbing.ErrReferenceNotFound: // unborn branch
+	default:
+		return nil, errors.Wrapf(err, "repo `%s`", repo.name)
+	}
+	if err := filepath.Walk(repo.path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			desc.DiskSize += info.Size()
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return desc, nil
+}
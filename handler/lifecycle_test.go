@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"github.com/fiibbb/gitdb/consts"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+	"testing"
+)
+
+// adminContext configures an admin token for `g` and returns a context
+// presenting it.
+func adminContext(g *GitHandler) context.Context {
+	g.cfg.AdminToken = "secret"
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(consts.AdminTokenKey, "secret"))
+}
+
+func TestDeleteRepo(t *testing.T) {
+	g := newTestHandler(t)
+	admin := adminContext(g)
+	old := testBatcher(g)
+
+	for _, ctx := range []context.Context{
+		context.Background(),
+		metadata.NewIncomingContext(context.Background(), metadata.Pairs(consts.AdminTokenKey, "wrong")),
+	} {
+		if _, err := g.DeleteRepo(ctx, "a", false); errors.Cause(err) != consts.ErrPermissionDenied {
+			t.Errorf("got error %v deleting without the admin token, want permission denied", err)
+		}
+	}
+	if _, err := g.repo("a"); err != nil {
+		t.Fatalf("repo gone after a denied delete: %v", err)
+	}
+
+	if _, err := g.DeleteRepo(admin, "a", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := g.batchers.Load("a"); ok {
+		t.Error("batcher of the deleted repo is left behind")
+	}
+	if _, err := g.CreateRepo(admin, "a", true); err != nil {
+		t.Fatal(err)
+	}
+	if b := g.batcher("a"); b == old {
+		t.Error("recreated repo reuses the batcher of the deleted one")
+	}
+}
@@ -1,10 +1,17 @@
 package handler
 
 import (
+	"encoding/hex"
+	ep "github.com/fiibbb/gitdb/extended_plumbing"
 	"github.com/fiibbb/gitdb/gitpb"
+	"github.com/fiibbb/gitdb/registry"
 	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
 	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
 	"io/ioutil"
+	"sort"
 )
 
 /*
@@ -12,6 +19,143 @@ import (
  * and go-git defined types.
  */
 
+func parseHash(s string) (plumbing.Hash, error) {
+	if len(s) != 40 {
+		return plumbing.ZeroHash, errors.Errorf("invalid hash `%s`", s)
+	}
+	if _, err := hex.DecodeString(s); err != nil {
+		return plumbing.ZeroHash, errors.Errorf("invalid hash `%s`", s)
+	}
+	return plumbing.NewHash(s), nil
+}
+
+func convertPreconditions(preconditions []*gitpb.Precondition) ([]ep.Precondition, error) {
+	var converted []ep.Precondition
+	for _, p := range preconditions {
+		if p.Absent == (p.Hash != "") {
+			return nil, errors.Errorf("precondition on `%s` needs exactly one of hash and absent", p.Path)
+		}
+		var hash plumbing.Hash
+		if !p.Absent {
+			var err error
+			if hash, err = parseHash(p.Hash); err != nil {
+				return nil, err
+			}
+		}
+		converted = append(converted, ep.Precondition{Path: p.Path, Hash: hash})
+	}
+	return converted, nil
+}
+
+// convertUpserts merges the plain upserts of `req` with its files, ordered by
+// path.
+func convertUpserts(req *gitpb.WriteCommitRequest) ([]ep.Upsert, error) {
+	var converted []ep.Upsert
+	for p, content := range req.Upserts {
+		converted = append(converted, ep.Upsert{Path: p, Content: content})
+	}
+	sort.Slice(converted, func(i, j int) bool {
+		return converted[i].Path < converted[j].Path
+	})
+	for _, f := range req.Files {
+		upsert := ep.Upsert{Path: f.Path, Content: f.Content}
+		switch f.Mode {
+		case gitpb.FileMode_REGULAR:
+			upsert.Mode = filemode.Regular
+		case gitpb.FileMode_EXECUTABLE:
+			upsert.Mode = filemode.Executable
+		case gitpb.FileMode_SYMLINK:
+			upsert.Mode = filemode.Symlink
+		case gitpb.FileMode_GITLINK:
+			upsert.Mode = filemode.Submodule
+		default:
+			return nil, errors.Errorf("unrecognized file mode `%v`", f.Mode)
+		}
+		if f.Gitlink != "" {
+			var err error
+			if upsert.Hash, err = parseHash(f.Gitlink); err != nil {
+				return nil, err
+			}
+		}
+		converted = append(converted, upsert)
+	}
+	return converted, nil
+}
+
+func convertFileMode(m filemode.FileMode) gitpb.FileMode {
+	switch m {
+	case filemode.Executable:
+		return gitpb.FileMode_EXECUTABLE
+	case filemode.Symlink:
+		return gitpb.FileMode_SYMLINK
+	case filemode.Submodule:
+		return gitpb.FileMode_GITLINK
+	default:
+		return gitpb.FileMode_REGULAR
+	}
+}
+
+func convertPatches(patches []*gitpb.Patch) ([]ep.Patch, error) {
+	var converted []ep.Patch
+	for _, p := range patches {
+		patch := ep.Patch{Path: p.Path, Content: p.Patch}
+		switch p.Type {
+		case gitpb.PatchType_MERGE_PATCH:
+			patch.Type = ep.MergePatch
+		case gitpb.PatchType_JSON_PATCH:
+			patch.Type = ep.JSONPatch
+		case gitpb.PatchType_UNIFIED_DIFF:
+			patch.Type = ep.UnifiedDiff
+		default:
+			return nil, errors.Errorf("unrecognized patch type `%v`", p.Type)
+		}
+		converted = append(converted, patch)
+	}
+	return converted, nil
+}
+
+func convertTraversal(t gitpb.Traversal) (ep.Traversal, error) {
+	switch t {
+	case gitpb.Traversal_FIRST_PARENT:
+		return ep.FirstParent, nil
+	case gitpb.Traversal_FULL_HISTORY:
+		return ep.FullHistory, nil
+	default:
+		return ep.FirstParent, errors.Errorf("unrecognized traversal `%v`", t)
+	}
+}
+
+func convertFileChanges(changes []*ep.FileChange) []*gitpb.FileChange {
+	var converted []*gitpb.FileChange
+	for _, c := range changes {
+		fc := &gitpb.FileChange{Path: c.Path, Diff: c.Diff}
+		switch c.Type {
+		case ep.Added:
+			fc.Type = gitpb.ChangeType_ADDED
+		case ep.Modified:
+			fc.Type = gitpb.ChangeType_MODIFIED
+		case ep.Deleted:
+			fc.Type = gitpb.ChangeType_DELETED
+		}
+		if !c.From.IsZero() {
+			fc.OldHash = c.From.String()
+		}
+		if !c.To.IsZero() {
+			fc.NewHash = c.To.String()
+		}
+		converted = append(converted, fc)
+	}
+	return converted
+}
+
+func convertMoves(moves []*gitpb.Move) []ep.Move {
+	var converted []ep.Move
+	for _, m := range moves {
+		converted = append(converted, ep.Move{From: m.From, To: m.To})
+	}
+	return converted
+}
+
 func convertBlob(b *object.Blob) (*gitpb.Blob, error) {
 	reader, err := b.Reader()
 	if err != nil {
@@ -29,14 +173,45 @@ func convertBlob(b *object.Blob) (*gitpb.Blob, error) {
 	return blob, nil
 }
 
+// convertFile converts the blob of the tree entry `entry`, which may also be a
+// gitlink without a blob.
+func convertFile(s storer.EncodedObjectStorer, entry *object.TreeEntry) (*gitpb.Blob, error) {
+	if entry.Mode == filemode.Submodule {
+		return &gitpb.Blob{Hash: entry.Hash.String(), Mode: gitpb.FileMode_GITLINK}, nil
+	}
+	b, err := object.GetBlob(s, entry.Hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "hash `%s`", entry.Hash)
+	}
+	blob, err := convertBlob(b)
+	if err != nil {
+		return nil, err
+	}
+	blob.Mode = convertFileMode(entry.Mode)
+	if entry.Mode == filemode.Symlink {
+		blob.SymlinkTarget = string(blob.Content)
+	}
+	return blob, nil
+}
+
 func convertTree(t *object.Tree) (*gitpb.Tree, error) {
 	var entries []*gitpb.TreeEntry
 	for _, entry := range t.Entries {
-		entries = append(entries, &gitpb.TreeEntry{
+		converted := &gitpb.TreeEntry{
 			Name: entry.Name,
 			Hash: entry.Hash.String(),
 			Mode: uint32(entry.Mode),
-		})
+		}
+		if entry.Mode == filemode.Symlink {
+			target, err := t.TreeEntryFile(&entry)
+			if err != nil {
+				return nil, errors.Wrapf(err, "symlink `%s`", entry.Name)
+			}
+			if converted.SymlinkTarget, err = target.Contents(); err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+		entries = append(entries, converted)
 	}
 	tree := &gitpb.Tree{
 		Hash:    t.Hash.String(),
@@ -72,6 +247,8 @@ func convertCommit(c *object.Commit) (*gitpb.Commit, error) {
 		Message:   c.Message,
 		Tree:      root.Hash.String(),
 		Parents:   parents,
+		Trailers:  ep.ParseTrailers(c.Message),
+		Signature: c.PGPSignature,
 		TreeObject: &gitpb.Object{
 			Obj: &gitpb.Object_Tree{
 				Tree: convertedRoot,
@@ -81,8 +258,8 @@ func convertCommit(c *object.Commit) (*gitpb.Commit, error) {
 	return commit, nil
 }
 
-func convertBlobObject(b *object.Blob) (*gitpb.Object, error) {
-	blob, err := convertBlob(b)
+func convertFileObject(s storer.EncodedObjectStorer, entry *object.TreeEntry) (*gitpb.Object, error) {
+	blob, err := convertFile(s, entry)
 	if err != nil {
 		return nil, err
 	}
@@ -104,3 +281,10 @@ func convertCommitObject(c *object.Commit) (*gitpb.Object, error) {
 	}
 	return &gitpb.Object{Obj: &gitpb.Object_Commit{Commit: commit}}, nil
 }
+
+func convertFailure(f *registry.Failure) *gitpb.Repo {
+	return &gitpb.Repo{
+		Name:  f.Name,
+		Error: f.Err.Error(),
+	}
+}
@@ -0,0 +1,443 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/fiibbb/gitdb/consts"
+	ep "github.com/fiibbb/gitdb/extended_plumbing"
+	"github.com/fiibbb/gitdb/gitpb"
+	"github.com/fiibbb/gitdb/validation"
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxWriteAttempts bounds how often WriteCommit starts over when the ref moves
+// between building a commit and updating the ref.
+const maxWriteAttempts = 3
+
+// write is a validated WriteCommitRequest.
+type write struct {
+	*gitpb.WriteCommitRequest
+	ref            string
+	expectedParent plumbing.Hash
+	preconditions  []ep.Precondition
+	upserts        []ep.Upsert
+	moves          []ep.Move
+	patches        []ep.Patch
+	admin          bool
+}
+
+func newWrite(req *gitpb.WriteCommitRequest, admin bool) (*write, error) {
+	w := &write{
+		WriteCommitRequest: req,
+		ref:                req.Ref,
+		admin:              admin,
+	}
+	if w.ref == "" {
+		w.ref = consts.RefNameMaster
+	}
+	if !plumbing.ReferenceName(w.ref).IsBranch() {
+		return nil, errors.Errorf("ref `%s` is not a branch", w.ref)
+	}
+	if req.ExpectAbsent && req.ExpectedParent != "" {
+		return nil, errors.Errorf("expectAbsent and expectedParent are mutually exclusive")
+	}
+	if req.Rebase && req.ExpectedParent == "" {
+		return nil, errors.Errorf("rebase requires expectedParent")
+	}
+	if req.ExpectedParent != "" {
+		var err error
+		if w.expectedParent, err = parseHash(req.ExpectedParent); err != nil {
+			return nil, err
+		}
+	}
+	if (req.Author.GetTime() != 0 || req.Committer.GetTime() != 0) && !admin {
+		return nil, errors.Wrapf(consts.ErrPermissionDenied, "explicit commit times require the admin token")
+	}
+	// Trailer values are trimmed when parsed back, so padded values would not
+	// read back as written, and padded idempotency keys would never match.
+	for k, v := range req.Metadata {
+		if strings.EqualFold(k, idempotencyTrailer) {
+			return nil, errors.Errorf("metadata key `%s` is reserved", k)
+		}
+		if strings.TrimSpace(v) != v {
+			return nil, errors.Errorf("value of metadata key `%s` has leading or trailing whitespace", k)
+		}
+	}
+	if strings.TrimSpace(req.IdempotencyKey) != req.IdempotencyKey {
+		return nil, errors.Errorf("idempotency key has leading or trailing whitespace")
+	}
+	for _, t := range w.trailers() {
+		if err := ep.ValidateTrailer(t); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if w.preconditions, err = convertPreconditions(req.Preconditions); err != nil {
+		return nil, err
+	}
+	if w.upserts, err = convertUpserts(req); err != nil {
+		return nil, err
+	}
+	w.moves = convertMoves(req.Moves)
+	if w.patches, err = convertPatches(req.Patches); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// trailers returns the metadata and idempotency key of the write ordered by
+// key.
+func (w *write) trailers() []ep.Trailer {
+	var trailers []ep.Trailer
+	for k, v := range w.Metadata {
+		trailers = append(trailers, ep.Trailer{Key: k, Value: v})
+	}
+	if w.IdempotencyKey != "" {
+		trailers = append(trailers, w.idempotencyTrailer())
+	}
+	sort.Slice(trailers, func(i, j int) bool {
+		return trailers[i].Key < trailers[j].Key
+	})
+	return trailers
+}
+
+// message returns the commit message of the write followed by its trailers.
+// Trailers cannot make up a message on their own, so a write without a message
+// gets a subject describing the paths it changes.
+func (w *write) message() string {
+	trailers := w.trailers()
+	if len(trailers) == 0 || strings.TrimSpace(w.Msg) != "" {
+		return ep.AppendTrailers(w.Msg, trailers)
+	}
+	var subject string
+	switch paths := append(w.paths(), w.patterns()...); len(paths) {
+	case 0:
+		subject = "Empty commit"
+	case 1:
+		subject = fmt.Sprintf("Update %s", paths[0])
+	default:
+		subject = fmt.Sprintf("Update %d paths", len(paths))
+	}
+	return ep.AppendTrailers(subject+"\n", trailers)
+}
+
+// batchable tells whether the write may be folded into a group commit. Writes
+// that expect a specific ref head or carry their own identity need a commit of
+// their own, and so do dry runs, empty commits and writes deleting by pattern,
+// whose paths are not known up front.
+func (w *write) batchable() bool {
+	return !w.ExpectAbsent && w.expectedParent.IsZero() && w.Author == nil && w.Committer == nil &&
+		!w.DryRun && !w.AllowEmpty && len(w.patterns()) == 0
+}
+
+// paths lists every path the write modifies, except for delete patterns.
+func (w *write) paths() []string {
+	var paths []string
+	for _, u := range w.upserts {
+		paths = append(paths, u.Path)
+	}
+	for _, m := range w.moves {
+		paths = append(paths, m.From, m.To)
+	}
+	for _, p := range w.patches {
+		paths = append(paths, p.Path)
+	}
+	for _, p := range w.Deletes {
+		if !ep.IsPattern(p) {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+func (w *write) changes() *ep.Changes {
+	return &ep.Changes{
+		Upserts: w.upserts,
+		Deletes: w.Deletes,
+		Moves:   w.moves,
+		Patches: w.patches,
+	}
+}
+
+// patterns lists the delete patterns of the write.
+func (w *write) patterns() []string {
+	var patterns []string
+	for _, p := range w.Deletes {
+		if ep.IsPattern(p) {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// claims lists every path the write modifies or depends on.
+func (w *write) claims() []string {
+	paths := w.paths()
+	for _, p := range w.preconditions {
+		paths = append(paths, p.Path)
+	}
+	return paths
+}
+
+// commit applies `w` on top of the ref head and advances the ref. It must be
+// called with the repo locked exclusively.
+func (g *GitHandler) commit(r *repository, w *write) (*gitpb.WriteCommitResponse, error) {
+	if resp, err := g.replay(r, w); resp != nil || err != nil {
+		return resp, err
+	}
+	for attempt := 1; ; attempt++ {
+		p, err := g.prepareCommit(r.Repository, w)
+		if err != nil {
+			return nil, err
+		}
+		if w.DryRun || p.unchanged {
+			return p.response()
+		}
+		err = ep.UpdateRef(r.Storer, w.ref, p.commit.Hash, p.parent)
+		if errors.Cause(err) == consts.ErrConflict && attempt < maxWriteAttempts {
+			// The ref was moved behind our back, e.g. by a push straight into
+			// the repo. Start over from the new head.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		g.syncIndex(r, w.ref)
+		return p.response()
+	}
+}
+
+// prepared is a commit that is stored but not yet reachable from its ref. The
+// commit is nil for a dry run, and the ref head itself if the write changes
+// nothing.
+type prepared struct {
+	commit *object.Commit
+	// parent is the ref head the commit was built on, zero for a new branch.
+	parent    plumbing.Hash
+	rebased   bool
+	unchanged bool
+	deleted   []string
+	// tree and changes are set for a dry run.
+	tree    plumbing.Hash
+	changes []*ep.FileChange
+}
+
+func (p *prepared) response() (*gitpb.WriteCommitResponse, error) {
+	resp := &gitpb.WriteCommitResponse{Rebased: p.rebased, Unchanged: p.unchanged, Deleted: p.deleted}
+	if p.commit != nil {
+		var err error
+		if resp.Commit, err = convertCommit(p.commit); err != nil {
+			return nil, err
+		}
+	}
+	if p.rebased {
+		resp.RebasedOnto = p.parent.String()
+	}
+	if !p.tree.IsZero() {
+		resp.Tree = p.tree.String()
+		resp.Changes = convertFileChanges(p.changes)
+	}
+	return resp, nil
+}
+
+// prepareCommit builds the commit for `w` on top of the current ref head. It
+// must be called with the repo locked exclusively.
+func (g *GitHandler) prepareCommit(r *git.Repository, w *write) (*prepared, error) {
+	p := &prepared{}
+	head, tree, err := headTree(r, w.ref)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case head == nil:
+		if !w.expectedParent.IsZero() {
+			return nil, errors.Wrapf(consts.ErrConflict, "ref `%s` does not exist, expected `%s`", w.ref, w.expectedParent)
+		}
+	case w.ExpectAbsent:
+		return nil, errors.Wrapf(consts.ErrConflict, "ref `%s` already exists at `%s`", w.ref, head.Hash)
+	case !w.expectedParent.IsZero() && head.Hash != w.expectedParent:
+		if !w.Rebase {
+			return nil, errors.Wrapf(consts.ErrConflict, "ref `%s` at `%s`, expected `%s`", w.ref, head.Hash, w.expectedParent)
+		}
+		if err := checkRebase(r, w, tree); err != nil {
+			return nil, err
+		}
+		p.rebased = true
+	}
+	var parents []plumbing.Hash
+	if head != nil {
+		p.parent = head.Hash
+		parents = []plumbing.Hash{head.Hash}
+	}
+	if err := ep.CheckPreconditions(tree, w.preconditions); err != nil {
+		return nil, err
+	}
+	var s storer.EncodedObjectStorer = r.Storer
+	if w.DryRun {
+		s = ep.NewOverlay(s)
+	}
+	newTree, deleted, err := ep.MakeTree(s, tree, w.changes())
+	if err != nil {
+		return nil, err
+	}
+	p.deleted = deleted
+	if err := checkProtected(tree, newTree, w.admin); err != nil {
+		return nil, err
+	}
+	if head != nil && newTree.Hash == head.TreeHash && !w.AllowEmpty {
+		p.commit = head
+		p.unchanged = true
+		return p, nil
+	}
+	var changes []*ep.FileChange
+	if w.DryRun || len(g.validators) > 0 {
+		if changes, err = ep.DiffTrees(tree, newTree, w.DryRun && w.TextDiff); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.validators.Validate(&validation.Write{
+		Repo:    w.Repo,
+		Ref:     w.ref,
+		Old:     tree,
+		New:     newTree,
+		Changes: changes,
+	}); err != nil {
+		return nil, err
+	}
+	if w.DryRun {
+		p.tree = newTree.Hash
+		p.changes = changes
+		return p, nil
+	}
+	author, committer, err := g.signatures(w, head)
+	if err != nil {
+		return nil, err
+	}
+	if p.commit, err = ep.CreateCommit(r.Storer, newTree.Hash, parents, author, committer, w.message(), g.signKey); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// signatures resolves the author and committer of `w`, falling back to the
+// server identity and the current time for anything left out. An explicit
+// committer time may neither precede the parent nor lie in the future, or
+// ResolveCommit would walk past the commit.
+func (g *GitHandler) signatures(w *write, head *object.Commit) (object.Signature, object.Signature, error) {
+	now := time.Now()
+	author := g.signatureOf(w.Author, now)
+	committer := g.signatureOf(w.Committer, now)
+	if w.Committer.GetTime() != 0 {
+		if committer.When.After(now) {
+			return author, committer, errors.Errorf("committer time `%s` is in the future", committer.When)
+		}
+		if head != nil && committer.When.Before(head.Committer.When) {
+			return author, committer, errors.Errorf("committer time `%s` precedes parent `%s` at `%s`", committer.When, head.Hash, head.Committer.When)
+		}
+	}
+	return author, committer, nil
+}
+
+func (g *GitHandler) signatureOf(sig *gitpb.Signature, now time.Time) object.Signature {
+	converted := g.signature(now)
+	if sig.GetName() != "" {
+		converted.Name = sig.Name
+	}
+	if sig.GetEmail() != "" {
+		converted.Email = sig.Email
+	}
+	if sig.GetTime() != 0 {
+		converted.When = time.Unix(sig.Time, 0)
+	}
+	return converted
+}
+
+// protectedDirs hold what rules the writes to a repo, which only admins may
+// change.
+var protectedDirs = []string{validation.PolicyDir, validation.SchemaDir}
+
+// checkProtected rejects changes to the protectedDirs of a repo unless `admin`
+// is set.
+func checkProtected(tree, newTree *object.Tree, admin bool) error {
+	if admin {
+		return nil
+	}
+	for _, dir := range protectedDirs {
+		var hashes [2]plumbing.Hash
+		for i, t := range []*object.Tree{tree, newTree} {
+			entry, err := ep.FindEntry(t, dir)
+			if err != nil {
+				return err
+			}
+			if entry != nil {
+				hashes[i] = entry.Hash
+			}
+		}
+		if hashes[0] != hashes[1] {
+			return errors.Wrapf(consts.ErrPermissionDenied, "changing `%s` requires the admin token", dir)
+		}
+	}
+	return nil
+}
+
+// headTree returns the commit `ref` points at and its tree, or nils if the ref
+// does not exist yet.
+func headTree(r *git.Repository, ref string) (*object.Commit, *object.Tree, error) {
+	head, err := ep.HeadCommit(r, ref)
+	if errors.Cause(err) == plumbing.ErrReferenceNotFound {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	tree, err := head.Tree()
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	return head, tree, nil
+}
+
+// checkRebase verifies that none of the paths `w` writes changed between the
+// expected parent and the head tree, so replaying `w` onto the head yields the
+// same content at those paths as applying it to the expected parent.
+func checkRebase(r *git.Repository, w *write, headTree *object.Tree) error {
+	base, err := r.CommitObject(w.expectedParent)
+	if err == plumbing.ErrObjectNotFound {
+		return errors.Wrapf(consts.ErrConflict, "expected parent `%s` not found", w.expectedParent)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "hash `%s`", w.expectedParent)
+	}
+	baseTree, err := base.Tree()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	changed, err := ep.ChangedPaths(baseTree, headTree)
+	if err != nil {
+		return err
+	}
+	overlapping, err := ep.OverlappingPaths(w.paths(), changed)
+	if err != nil {
+		return err
+	}
+	for _, pattern := range w.patterns() {
+		if pattern, err = ep.CleanDelete(pattern); err != nil {
+			return err
+		}
+		for _, c := range changed {
+			if ep.MatchPattern(pattern, c) {
+				overlapping = append(overlapping, c)
+			}
+		}
+	}
+	if len(overlapping) > 0 {
+		return errors.Wrapf(consts.ErrConflict, "cannot rebase onto ref `%s`, paths changed since `%s`: %s", w.ref, w.expectedParent, strings.Join(overlapping, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"github.com/fiibbb/gitdb/consts"
+	"github.com/fiibbb/gitdb/gitpb"
+	"github.com/fiibbb/gitdb/validation"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+	"testing"
+	"time"
+)
+
+func TestWriteMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *gitpb.WriteCommitRequest
+		want string
+	}{
+		{
+			name: "message only",
+			req:  &gitpb.WriteCommitRequest{Msg: "m\n", Upserts: map[string][]byte{"a": nil}},
+			want: "m\n",
+		},
+		{
+			name: "message and metadata",
+			req:  &gitpb.WriteCommitRequest{Msg: "m\n", Upserts: map[string][]byte{"a": nil}, Metadata: map[string]string{"Ticket": "T-1"}},
+			want: "m\n\nTicket: T-1\n",
+		},
+		{
+			name: "metadata of a single path",
+			req:  &gitpb.WriteCommitRequest{Upserts: map[string][]byte{"a/b": nil}, Metadata: map[string]string{"Ticket": "T-1"}},
+			want: "Update a/b\n\nTicket: T-1\n",
+		},
+		{
+			name: "idempotency key of several paths",
+			req:  &gitpb.WriteCommitRequest{Upserts: map[string][]byte{"a": nil}, Deletes: []string{"b", "c/*"}, IdempotencyKey: "k"},
+			want: "Update 3 paths\n\n" + idempotencyTrailer + ": k\n",
+		},
+		{
+			name: "metadata of an empty commit",
+			req:  &gitpb.WriteCommitRequest{AllowEmpty: true, Msg: " \n", Metadata: map[string]string{"Ticket": "T-1"}},
+			want: "Empty commit\n\nTicket: T-1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := newWrite(tt.req, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := w.message(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWritePadding(t *testing.T) {
+	for _, req := range []*gitpb.WriteCommitRequest{
+		{Msg: "m", Metadata: map[string]string{"Ticket": " T-1"}},
+		{Msg: "m", Metadata: map[string]string{"Ticket": "T-1\n"}},
+		{Msg: "m", IdempotencyKey: "k "},
+		{Msg: "m", IdempotencyKey: "\tk"},
+	} {
+		if _, err := newWrite(req, false); err == nil {
+			t.Errorf("accepted padded metadata %q or idempotency key %q", req.Metadata, req.IdempotencyKey)
+		}
+	}
+}
+
+func TestCheckProtected(t *testing.T) {
+	g := newTestHandler(t)
+	ctx := context.Background()
+	for _, dir := range protectedDirs {
+		req := &gitpb.WriteCommitRequest{Repo: "a", Msg: "m", Upserts: map[string][]byte{dir + "/f": []byte("{}")}}
+		_, err := g.WriteCommit(ctx, req)
+		if errors.Cause(err) != consts.ErrPermissionDenied {
+			t.Errorf("got error %v writing `%s` without the admin token, want permission denied", err, dir)
+		}
+	}
+	// Other paths under the same parent stay writable.
+	req := &gitpb.WriteCommitRequest{Repo: "a", Msg: "m", Upserts: map[string][]byte{".gitdb/other": []byte("x")}}
+	if _, err := g.WriteCommit(ctx, req); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExplicitTimes(t *testing.T) {
+	t.Setenv("GITDB_ADMIN_TOKEN", "secret")
+	g := newTestHandler(t)
+	if _, err := g.CreateRepo(context.Background(), "b", false); err != nil {
+		t.Fatal(err)
+	}
+	backfilled := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC).Unix()
+	req := &gitpb.WriteCommitRequest{
+		Repo:      "b",
+		Msg:       "m",
+		Upserts:   map[string][]byte{"a": []byte("a")},
+		Author:    &gitpb.Signature{Name: "a", Email: "a@localhost", Time: backfilled},
+		Committer: &gitpb.Signature{Name: "c", Email: "c@localhost", Time: backfilled},
+	}
+	for _, token := range []string{"", "wrong"} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(consts.AdminTokenKey, token))
+		if _, err := g.WriteCommit(ctx, req); errors.Cause(err) != consts.ErrPermissionDenied {
+			t.Errorf("got error %v with token %q, want permission denied", err, token)
+		}
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(consts.AdminTokenKey, "secret"))
+	resp, err := g.WriteCommit(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Commit.Author.Time; got != backfilled {
+		t.Errorf("got author time %d, want %d", got, backfilled)
+	}
+	if got := resp.Commit.Committer.Time; got != backfilled {
+		t.Errorf("got committer time %d, want %d", got, backfilled)
+	}
+}
+
+func TestAdminWritesSchemas(t *testing.T) {
+	g := newTestHandler(t, validation.NewSchemaValidator().Validator)
+	admin := adminContext(g)
+	req := &gitpb.WriteCommitRequest{Repo: "a", Msg: "install schemas", Upserts: map[string][]byte{
+		validation.SchemaIndex:                []byte(`{"*.json": "object.json"}`),
+		validation.SchemaDir + "/object.json": []byte(`{"type": "object"}`),
+	}}
+	if _, err := g.WriteCommit(admin, req); err != nil {
+		t.Fatal(err)
+	}
+
+	// The installed schemas apply to later writes.
+	req = &gitpb.WriteCommitRequest{Repo: "a", Msg: "m", Upserts: map[string][]byte{"a.json": []byte(`[]`)}}
+	_, err := g.WriteCommit(context.Background(), req)
+	if _, ok := err.(*validation.Error); !ok {
+		t.Errorf("got error %v, want a validation error", err)
+	}
+}
+
+func TestAdminWritesPolicies(t *testing.T) {
+	g := newTestHandler(t, validation.NewPolicyValidator(zap.NewNop()).Validator)
+	admin := adminContext(g)
+	policy := map[string][]byte{validation.PolicyDir + "/frozen.star": []byte(`
+def check(write):
+    for c in write.changes:
+        if c.path.startswith("frozen/"):
+            reject("frozen", path=c.path)
+`)}
+	if _, err := g.WriteCommit(context.Background(), &gitpb.WriteCommitRequest{Repo: "a", Msg: "m", Upserts: policy}); errors.Cause(err) != consts.ErrPermissionDenied {
+		t.Errorf("got error %v installing a policy without the admin token, want permission denied", err)
+	}
+	if _, err := g.WriteCommit(admin, &gitpb.WriteCommitRequest{Repo: "a", Msg: "install policy", Upserts: policy}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The installed policy applies to later writes.
+	req := &gitpb.WriteCommitRequest{Repo: "a", Msg: "m", Upserts: map[string][]byte{"frozen/a": []byte("a")}}
+	if _, err := g.WriteCommit(context.Background(), req); err == nil {
+		t.Error("policy accepted a write it rejects")
+	} else if _, ok := err.(*validation.Error); !ok {
+		t.Errorf("got error %v, want a validation error", err)
+	}
+	req = &gitpb.WriteCommitRequest{Repo: "a", Msg: "m", Upserts: map[string][]byte{"open/a": []byte("a")}}
+	if _, err := g.WriteCommit(context.Background(), req); err != nil {
+		t.Error(err)
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"github.com/fiibbb/gitdb/config"
 	"github.com/fiibbb/gitdb/handler"
+	"github.com/fiibbb/gitdb/registry"
+	"github.com/fiibbb/gitdb/validation"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -12,6 +14,11 @@ func main() {
 		fx.Provide(
 			zap.NewDevelopment,
 			config.NewAppConfig,
+			registry.NewRegistry,
+			validation.NewValidators,
+			validation.NewJSONValidator,
+			validation.NewSchemaValidator,
+			validation.NewPolicyValidator,
 			handler.NewGitHandler,
 			handler.NewGRPCService,
 			handler.NewHTTPService,
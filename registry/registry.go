@@ -0,0 +1,167 @@
+package registry
+
+import (
+	"fmt"
+	"github.com/fiibbb/gitdb/config"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"gopkg.in/src-d/go-git.v4"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	bareSuffix = ".git"
+	archiveDir = ".archive"
+)
+
+// Repo is a bare repository discovered under the registry root.
+type Repo struct {
+	Name string
+	Path string
+	*git.Repository
+}
+
+// Failure is a directory under the registry root that looks like a bare
+// repository but could not be opened.
+type Failure struct {
+	Name string
+	Path string
+	Err  error
+}
+
+// Registry discovers bare repositories under a root directory and maps them to
+// stable names. The name of a repository is its path relative to the root with
+// the `.git` suffix trimmed, e.g. `<root>/team/config.git` is `team/config`.
+type Registry struct {
+	root   string
+	logger *zap.Logger
+}
+
+func NewRegistry(cfg *config.AppConfig, logger *zap.Logger) (*Registry, error) {
+	root, err := filepath.Abs(cfg.RepoRoot)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &Registry{
+		root:   root,
+		logger: logger,
+	}, nil
+}
+
+func (r *Registry) Root() string {
+	return r.root
+}
+
+// Scan walks the root directory and opens every bare repository it finds.
+// Directories starting with `.` and a repository at the root itself are
+// skipped, and the walk does not descend into other repositories. Repositories that fail to open are reported in the failures.
+func (r *Registry) Scan() ([]*Repo, []*Failure, error) {
+	var repos []*Repo
+	var failures []*Failure
+	seen := map[string]string{}
+	err := filepath.Walk(r.root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if p != r.root && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if !isBareRepo(p) {
+			return nil
+		}
+		if p == r.root {
+			// The root has no name to serve it under.
+			r.logger.Warn("Skipping repo at the registry root", zap.String("path", p))
+			return nil
+		}
+		name, err := r.NameOf(p)
+		if err != nil {
+			return err
+		}
+		if other, ok := seen[name]; ok {
+			failures = append(failures, &Failure{
+				Name: name,
+				Path: p,
+				Err:  errors.Errorf("repo name `%s` already taken by `%s`", name, other),
+			})
+			return filepath.SkipDir
+		}
+		seen[name] = p
+		repo, err := git.PlainOpen(p)
+		if err != nil {
+			failures = append(failures, &Failure{Name: name, Path: p, Err: errors.WithStack(err)})
+		} else {
+			repos = append(repos, &Repo{Name: name, Path: p, Repository: repo})
+		}
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "scan `%s`", r.root)
+	}
+	return repos, failures, nil
+}
+
+// NameOf returns the repo name for a repository directory under the root.
+func (r *Registry) NameOf(p string) (string, error) {
+	rel, err := filepath.Rel(r.root, p)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if rel == "." || strings.HasPrefix(rel, "..") {
+		return "", errors.Errorf("path `%s` not under root `%s`", p, r.root)
+	}
+	return strings.TrimSuffix(filepath.ToSlash(rel), bareSuffix), nil
+}
+
+// PathOf returns the directory a repository named `name` lives in.
+func (r *Registry) PathOf(name string) (string, error) {
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+	return filepath.Join(r.root, filepath.FromSlash(name)+bareSuffix), nil
+}
+
+// ArchivePathOf returns where a repository named `name` is moved to when it is
+// archived at time `t`. Archives are hidden from Scan.
+func (r *Registry) ArchivePathOf(name string, t time.Time) string {
+	return filepath.Join(r.root, archiveDir, fmt.Sprintf("%s.%d%s", filepath.FromSlash(name), t.Unix(), bareSuffix))
+}
+
+// ValidateName checks that `name` is a clean, relative, slash separated path
+// without hidden components.
+func ValidateName(name string) error {
+	if name == "" {
+		return errors.Errorf("empty repo name")
+	}
+	if filepath.ToSlash(filepath.Clean(filepath.FromSlash(name))) != name || strings.HasPrefix(name, "/") {
+		return errors.Errorf("repo name `%s` is not a clean relative path", name)
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." || strings.HasPrefix(part, ".") {
+			return errors.Errorf("repo name `%s` has invalid component `%s`", name, part)
+		}
+	}
+	return nil
+}
+
+// isBareRepo checks for the minimal layout of a bare repository.
+func isBareRepo(p string) bool {
+	if info, err := os.Stat(filepath.Join(p, "HEAD")); err != nil || info.IsDir() {
+		return false
+	}
+	for _, dir := range []string{"objects", "refs"} {
+		if info, err := os.Stat(filepath.Join(p, dir)); err != nil || !info.IsDir() {
+			return false
+		}
+	}
+	return true
+}
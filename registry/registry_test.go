@@ -0,0 +1,95 @@
+package registry
+
+import (
+	"github.com/fiibbb/gitdb/config"
+	"go.uber.org/zap"
+	"gopkg.in/src-d/go-git.v4"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func newTestRegistry(t *testing.T) *Registry {
+	root, err := ioutil.TempDir("", "gitdb-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(root) })
+	r, err := NewRegistry(&config.AppConfig{RepoRoot: root}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func initBare(t *testing.T, p string) {
+	if _, err := git.PlainInit(p, true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func scanNames(t *testing.T, r *Registry) (names, failed []string) {
+	repos, failures, err := r.Scan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, repo := range repos {
+		names = append(names, repo.Name)
+	}
+	for _, failure := range failures {
+		failed = append(failed, failure.Name)
+	}
+	sort.Strings(names)
+	sort.Strings(failed)
+	return names, failed
+}
+
+func TestScan(t *testing.T) {
+	r := newTestRegistry(t)
+	for _, p := range []string{"a.git", "team/config.git", "team/flags", "dup", "dup.git", ".archive/old.git", "a.git/nested.git"} {
+		initBare(t, filepath.Join(r.Root(), filepath.FromSlash(p)))
+	}
+	if err := os.MkdirAll(filepath.Join(r.Root(), "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	names, failed := scanNames(t, r)
+	if want := []string{"a", "dup", "team/config", "team/flags"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got repos %v, want %v", names, want)
+	}
+	if want := []string{"dup"}; !reflect.DeepEqual(failed, want) {
+		t.Errorf("got failures %v, want %v", failed, want)
+	}
+}
+
+func TestScanRootRepo(t *testing.T) {
+	r := newTestRegistry(t)
+	initBare(t, r.Root())
+	initBare(t, filepath.Join(r.Root(), "a.git"))
+
+	names, failed := scanNames(t, r)
+	if want := []string{"a"}; !reflect.DeepEqual(names, want) || len(failed) != 0 {
+		t.Errorf("got repos %v and failures %v, want %v", names, failed, want)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	for name, valid := range map[string]bool{
+		"a":           true,
+		"team/config": true,
+		"":            false,
+		"/a":          false,
+		"a/":          false,
+		"a//b":        false,
+		"a/../b":      false,
+		".archive/a":  false,
+		"team/.a":     false,
+	} {
+		if err := ValidateName(name); (err == nil) != valid {
+			t.Errorf("name `%s`: got error %v, want valid %v", name, err, valid)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"encoding/json"
+	"github.com/fiibbb/gitdb/config"
+	ep "github.com/fiibbb/gitdb/extended_plumbing"
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"strings"
+)
+
+// jsonValidator rejects writes leaving malformed `.json` files in the repos
+// that opted in.
+type jsonValidator struct {
+	repos map[string]bool
+}
+
+func NewJSONValidator(cfg *config.AppConfig) Out {
+	return Out{Validator: jsonValidator{repos: cfg.ValidateJSONRepos}}
+}
+
+func (v jsonValidator) Validate(w *Write) ([]*Failure, error) {
+	if !v.repos[w.Repo] {
+		return nil, nil
+	}
+	var failures []*Failure
+	for _, c := range w.Changes {
+		if c.Type == ep.Deleted || !strings.HasSuffix(c.Path, ".json") {
+			continue
+		}
+		entry, err := ep.FindEntry(w.New, c.Path)
+		if err != nil {
+			return nil, err
+		}
+		if entry.Mode != filemode.Regular && entry.Mode != filemode.Executable {
+			continue
+		}
+		file, err := w.New.TreeEntryFile(entry)
+		if err != nil {
+			return nil, errors.Wrapf(err, "path `%s`", c.Path)
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if _, err := parseJSON([]byte(content)); err != nil {
+			failures = append(failures, &Failure{Path: c.Path, Reason: err.Error()})
+		}
+	}
+	return failures, nil
+}
+
+// parseJSON decodes `content`. The error of malformed JSON is the same for
+// every validator, so that Validators reports it once.
+func parseJSON(content []byte) (interface{}, error) {
+	var doc interface{}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, errors.Errorf("malformed JSON: %s", err)
+	}
+	return doc, nil
+}
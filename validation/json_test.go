@@ -0,0 +1,59 @@
+package validation
+
+import (
+	"github.com/fiibbb/gitdb/config"
+	ep "github.com/fiibbb/gitdb/extended_plumbing"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+	"reflect"
+	"testing"
+)
+
+// newWrite returns a write of `files` to an empty tree of `repo`.
+func newWrite(t *testing.T, repo string, files map[string]string) *Write {
+	c := &ep.Changes{}
+	for p, content := range files {
+		c.Upserts = append(c.Upserts, ep.Upsert{Path: p, Content: []byte(content)})
+	}
+	tree, _, err := ep.MakeTree(memory.NewStorage(), nil, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes, err := ep.DiffTrees(nil, tree, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Write{Repo: repo, Ref: "refs/heads/master", New: tree, Changes: changes}
+}
+
+func TestJSONValidator(t *testing.T) {
+	cfg := &config.AppConfig{ValidateJSONRepos: map[string]bool{"strict": true}}
+	validators := Validators{NewJSONValidator(cfg).Validator, NewSchemaValidator().Validator}
+	files := map[string]string{
+		SchemaIndex:                `{"*.json": "object.json"}`,
+		SchemaDir + "/object.json": `{"type": "object"}`,
+		"a.json":                   `{"a": `,
+		"b.json":                   `{}`,
+		"c.txt":                    `{`,
+	}
+
+	err := validators.Validate(newWrite(t, "strict", files))
+	verr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("got error %v, want a validation error", err)
+	}
+	want := []*Failure{{Path: "a.json", Reason: "malformed JSON: unexpected end of JSON input"}}
+	if !reflect.DeepEqual(verr.Failures, want) {
+		t.Errorf("got failures %v, want %v", verr, want)
+	}
+
+	// Without opting in only the schemas check the file.
+	err = validators.Validate(newWrite(t, "lenient", files))
+	verr, ok = err.(*Error)
+	if !ok || !reflect.DeepEqual(verr.Failures, want) {
+		t.Errorf("got error %v, want failures %v", err, want)
+	}
+	delete(files, SchemaIndex)
+	if err := validators.Validate(newWrite(t, "lenient", files)); err != nil {
+		t.Errorf("got error %v without schemas or opting in", err)
+	}
+}
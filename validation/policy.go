@@ -0,0 +1,276 @@
+package validation
+
+import (
+	"fmt"
+	ep "github.com/fiibbb/gitdb/extended_plumbing"
+	"github.com/pkg/errors"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.uber.org/zap"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// PolicyDir is the directory of a tree reserved for Starlark policies.
+	// Only admins may change it.
+	PolicyDir = ".gitdb/policies"
+
+	policySuffix   = ".star"
+	policyMaxSteps = 10000000
+	policyTimeout  = 5 * time.Second
+)
+
+// policyValidator runs the Starlark policies of a tree on every write to it.
+// A policy is a `.star` file under PolicyDir defining `check(write)`, which
+// calls `reject(reason, path=None)` for everything wrong with the write. The
+// write has the fields `repo`, `ref`, `changes`, `old` and `new`, where the
+// trees provide `exists(path)`, `read(path)`, `parse(path)` for decoded JSON
+// or YAML, and `files(prefix="")`. Scripts cannot load modules or do any I/O,
+// and are cut off after policyMaxSteps steps or policyTimeout. A policy that
+// fails to run rejects the write. What a policy prints is only logged, for
+// debugging policies.
+//
+// The policies are taken from the new tree. Only admins may change them, so
+// this is the policies of the ref head except when an admin installs or fixes
+// policies, which then have to accept their own write.
+type policyValidator struct {
+	logger *zap.Logger
+}
+
+func NewPolicyValidator(logger *zap.Logger) Out {
+	return Out{Validator: &policyValidator{logger: logger}}
+}
+
+func (v *policyValidator) Validate(w *Write) ([]*Failure, error) {
+	dir, err := ep.FindEntry(w.New, PolicyDir)
+	if err != nil || dir == nil || dir.Mode != filemode.Dir {
+		return nil, err
+	}
+	policies, err := w.New.Tree(PolicyDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "path `%s`", PolicyDir)
+	}
+	var names []string
+	for _, entry := range policies.Entries {
+		if entry.Mode == filemode.Regular && strings.HasSuffix(entry.Name, policySuffix) {
+			names = append(names, entry.Name)
+		}
+	}
+	sort.Strings(names)
+	arg := writeValue(w)
+	var failures []*Failure
+	for _, name := range names {
+		p := path.Join(PolicyDir, name)
+		src, _, err := readFile(w.New, p)
+		if err != nil {
+			return nil, err
+		}
+		f, err := v.run(p, src, arg)
+		if err != nil {
+			failures = append(failures, &Failure{Path: p, Reason: err.Error()})
+			continue
+		}
+		failures = append(failures, f...)
+	}
+	return failures, nil
+}
+
+// run evaluates the policy `p` and calls its check function on `arg`,
+// returning the rejections. The error is for policies that fail to run.
+func (v *policyValidator) run(p string, src []byte, arg starlark.Value) ([]*Failure, error) {
+	var failures []*Failure
+	thread := &starlark.Thread{
+		Name: p,
+		Print: func(_ *starlark.Thread, msg string) {
+			v.logger.Info("Policy output", zap.String("policy", p), zap.String("msg", msg))
+		},
+	}
+	thread.SetMaxExecutionSteps(policyMaxSteps)
+	timer := time.AfterFunc(policyTimeout, func() { thread.Cancel("timeout") })
+	defer timer.Stop()
+
+	reject := starlark.NewBuiltin("reject", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var reason string
+		var rejected starlark.Value = starlark.None
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs, "reason", &reason, "path?", &rejected); err != nil {
+			return nil, err
+		}
+		f := &Failure{Reason: reason}
+		if s, ok := starlark.AsString(rejected); ok {
+			f.Path = s
+		}
+		failures = append(failures, f)
+		return starlark.None, nil
+	})
+	globals, err := starlark.ExecFile(thread, p, src, starlark.StringDict{"reject": reject})
+	if err != nil {
+		return nil, err
+	}
+	check, ok := globals["check"].(starlark.Callable)
+	if !ok {
+		return nil, errors.Errorf("policy defines no check function")
+	}
+	if _, err := starlark.Call(thread, check, starlark.Tuple{arg}, nil); err != nil {
+		return nil, err
+	}
+	return failures, nil
+}
+
+func writeValue(w *Write) starlark.Value {
+	var changes []starlark.Value
+	for _, c := range w.Changes {
+		changes = append(changes, starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+			"path":     starlark.String(c.Path),
+			"type":     starlark.String(changeTypes[c.Type]),
+			"old_hash": hashValue(c.From.IsZero(), c.From.String()),
+			"new_hash": hashValue(c.To.IsZero(), c.To.String()),
+		}))
+	}
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"repo":    starlark.String(w.Repo),
+		"ref":     starlark.String(w.Ref),
+		"changes": starlark.NewList(changes),
+		"old":     treeValue(w.Old),
+		"new":     treeValue(w.New),
+	})
+}
+
+var changeTypes = map[ep.ChangeType]string{
+	ep.Added:    "added",
+	ep.Modified: "modified",
+	ep.Deleted:  "deleted",
+}
+
+func hashValue(zero bool, hash string) starlark.Value {
+	if zero {
+		return starlark.None
+	}
+	return starlark.String(hash)
+}
+
+// treeValue exposes `tree` to policies, or None for an empty tree.
+func treeValue(tree *object.Tree) starlark.Value {
+	if tree == nil {
+		return starlark.None
+	}
+	pathArg := func(b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (string, error) {
+		var p string
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs, "path", &p); err != nil {
+			return "", err
+		}
+		return ep.CleanPath(p)
+	}
+	read := func(b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (string, []byte, bool, error) {
+		p, err := pathArg(b, args, kwargs)
+		if err != nil {
+			return "", nil, false, err
+		}
+		content, ok, err := readFile(tree, p)
+		return p, content, ok, err
+	}
+	return starlarkstruct.FromStringDict(starlark.String("tree"), starlark.StringDict{
+		"exists": starlark.NewBuiltin("exists", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			p, err := pathArg(b, args, kwargs)
+			if err != nil {
+				return nil, err
+			}
+			entry, err := ep.FindEntry(tree, p)
+			return starlark.Bool(entry != nil), err
+		}),
+		"read": starlark.NewBuiltin("read", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			_, content, ok, err := read(b, args, kwargs)
+			if err != nil || !ok {
+				return starlark.None, err
+			}
+			return starlark.String(content), nil
+		}),
+		"parse": starlark.NewBuiltin("parse", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			p, content, ok, err := read(b, args, kwargs)
+			if err != nil || !ok {
+				return starlark.None, err
+			}
+			doc, err := decode(p, content)
+			if err != nil {
+				return nil, err
+			}
+			return toStarlark(doc)
+		}),
+		"files": starlark.NewBuiltin("files", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var prefix string
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "prefix?", &prefix); err != nil {
+				return nil, err
+			}
+			files, err := treeFiles(tree)
+			if err != nil {
+				return nil, err
+			}
+			var matching []starlark.Value
+			for _, f := range files {
+				if strings.HasPrefix(f, prefix) {
+					matching = append(matching, starlark.String(f))
+				}
+			}
+			return starlark.NewList(matching), nil
+		}),
+	})
+}
+
+// toStarlark converts a decoded JSON or YAML document.
+func toStarlark(v interface{}) (starlark.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return starlark.None, nil
+	case bool:
+		return starlark.Bool(v), nil
+	case string:
+		return starlark.String(v), nil
+	case int:
+		return starlark.MakeInt(v), nil
+	case int64:
+		return starlark.MakeInt64(v), nil
+	case uint:
+		return starlark.MakeUint(v), nil
+	case uint64:
+		return starlark.MakeUint64(v), nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return starlark.MakeInt64(int64(v)), nil
+		}
+		return starlark.Float(v), nil
+	case []interface{}:
+		var elems []starlark.Value
+		for _, e := range v {
+			converted, err := toStarlark(e)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, converted)
+		}
+		return starlark.NewList(elems), nil
+	case map[string]interface{}:
+		var keys []string
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		dict := starlark.NewDict(len(v))
+		for _, k := range keys {
+			converted, err := toStarlark(v[k])
+			if err != nil {
+				return nil, err
+			}
+			if err := dict.SetKey(starlark.String(k), converted); err != nil {
+				return nil, err
+			}
+		}
+		return dict, nil
+	default:
+		return nil, errors.Errorf("cannot convert `%s` to Starlark", fmt.Sprint(v))
+	}
+}
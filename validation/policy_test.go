@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"go.uber.org/zap"
+	"reflect"
+	"testing"
+)
+
+func TestPolicyValidator(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		want   []*Failure
+	}{
+		{
+			name: "accepted",
+			policy: `
+def check(write):
+    print("checked", write.repo)
+`,
+		},
+		{
+			name: "rejected",
+			policy: `
+def check(write):
+    print("checking", len(write.changes), "changes")
+    reject("no", path="a.yaml")
+    print("done")
+`,
+			// What the policy prints is not a failure.
+			want: []*Failure{{Path: "a.yaml", Reason: "no"}},
+		},
+		{
+			name: "failed",
+			policy: `
+def check(write):
+    print("before")
+    fail("oops")
+`,
+			want: []*Failure{{Path: PolicyDir + "/p.star", Reason: "fail: oops"}},
+		},
+		{
+			name: "large integers",
+			policy: `
+def check(write):
+    doc = write.new.parse("a.yaml")
+    if doc["big"] != 18446744073709551615 or doc["small"] != -9223372036854775808:
+        reject("got %r" % doc)
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newWrite(t, "a", map[string]string{
+				PolicyDir + "/p.star": tt.policy,
+				"a.yaml":              "big: 18446744073709551615\nsmall: -9223372036854775808\n",
+			})
+			got, err := NewPolicyValidator(zap.NewNop()).Validator.Validate(w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got failures %v, want %v", (&Error{Failures: got}).Error(), (&Error{Failures: tt.want}).Error())
+			}
+		})
+	}
+}
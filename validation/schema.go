@@ -0,0 +1,203 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	ep "github.com/fiibbb/gitdb/extended_plumbing"
+	"github.com/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/yaml.v2"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	// SchemaDir is the directory of a tree reserved for JSON Schemas. Only
+	// admins may change it.
+	SchemaDir = ".gitdb/schemas"
+	// SchemaIndex maps path patterns, matched as in ep.MatchPattern, to the
+	// schema files under SchemaDir the files at those paths must conform to.
+	SchemaIndex = SchemaDir + "/index.json"
+)
+
+// schemaValidator enforces the schemas of a tree on the files of a write.
+type schemaValidator struct{}
+
+func NewSchemaValidator() Out {
+	return Out{Validator: schemaValidator{}}
+}
+
+func (schemaValidator) Validate(w *Write) ([]*Failure, error) {
+	var paths []string
+	for _, c := range w.Changes {
+		if strings.HasPrefix(c.Path, SchemaDir+"/") {
+			// The schemas changed, check everything against them.
+			return ValidateTree(w.New)
+		}
+		if c.Type != ep.Deleted {
+			paths = append(paths, c.Path)
+		}
+	}
+	return validateSchemas(w.New, paths)
+}
+
+// ValidateTree checks every file of `tree` against the schemas of `tree`.
+func ValidateTree(tree *object.Tree) ([]*Failure, error) {
+	paths, err := treeFiles(tree)
+	if err != nil {
+		return nil, err
+	}
+	return validateSchemas(tree, paths)
+}
+
+// treeFiles lists the paths of all files of `tree`.
+func treeFiles(tree *object.Tree) ([]string, error) {
+	var paths []string
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			return paths, nil
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if entry.Mode != filemode.Dir {
+			paths = append(paths, name)
+		}
+	}
+}
+
+type schemaRule struct {
+	pattern string
+	path    string
+	schema  *gojsonschema.Schema
+}
+
+// validateSchemas checks the files at `paths` in `tree` against the schemas of
+// `tree`.
+func validateSchemas(tree *object.Tree, paths []string) ([]*Failure, error) {
+	rules, failures, err := loadSchemas(tree)
+	if err != nil || len(failures) > 0 {
+		return failures, err
+	}
+	for _, p := range paths {
+		if strings.HasPrefix(p, SchemaDir+"/") {
+			continue
+		}
+		var matching []*schemaRule
+		for _, rule := range rules {
+			if ep.MatchPattern(rule.pattern, p) {
+				matching = append(matching, rule)
+			}
+		}
+		if len(matching) == 0 {
+			continue
+		}
+		content, ok, err := readFile(tree, p)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		doc, err := decode(p, content)
+		if err != nil {
+			failures = append(failures, &Failure{Path: p, Reason: err.Error()})
+			continue
+		}
+		for _, rule := range matching {
+			result, err := rule.schema.Validate(gojsonschema.NewGoLoader(doc))
+			if err != nil {
+				failures = append(failures, &Failure{Path: p, Reason: err.Error()})
+				continue
+			}
+			for _, e := range result.Errors() {
+				failures = append(failures, &Failure{Path: p, Reason: fmt.Sprintf("schema `%s`: %s", rule.path, e)})
+			}
+		}
+	}
+	return failures, nil
+}
+
+// loadSchemas reads the schema index of `tree` and the schemas it refers to.
+// Problems with the schemas themselves are failures of their paths.
+func loadSchemas(tree *object.Tree) ([]*schemaRule, []*Failure, error) {
+	content, ok, err := readFile(tree, SchemaIndex)
+	if err != nil || !ok {
+		return nil, nil, err
+	}
+	index := map[string]string{}
+	if err := json.Unmarshal(content, &index); err != nil {
+		return nil, []*Failure{{Path: SchemaIndex, Reason: err.Error()}}, nil
+	}
+	var rules []*schemaRule
+	var failures []*Failure
+	for pattern, schemaPath := range index {
+		p, err := ep.CleanDelete(pattern)
+		if err != nil {
+			failures = append(failures, &Failure{Path: SchemaIndex, Reason: err.Error()})
+			continue
+		}
+		rule := &schemaRule{pattern: p, path: path.Join(SchemaDir, schemaPath)}
+		if !strings.HasPrefix(rule.path, SchemaDir+"/") {
+			failures = append(failures, &Failure{Path: SchemaIndex, Reason: fmt.Sprintf("schema `%s` is outside of `%s`", schemaPath, SchemaDir)})
+			continue
+		}
+		schema, ok, err := readFile(tree, rule.path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			failures = append(failures, &Failure{Path: SchemaIndex, Reason: fmt.Sprintf("schema `%s` does not exist", rule.path)})
+			continue
+		}
+		if rule.schema, err = gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema)); err != nil {
+			failures = append(failures, &Failure{Path: rule.path, Reason: err.Error()})
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].pattern < rules[j].pattern
+	})
+	return rules, failures, nil
+}
+
+// readFile returns the content of the regular file at `p`, or false if there
+// is none.
+func readFile(tree *object.Tree, p string) ([]byte, bool, error) {
+	entry, err := ep.FindEntry(tree, p)
+	if err != nil || entry == nil {
+		return nil, false, err
+	}
+	if entry.Mode != filemode.Regular && entry.Mode != filemode.Executable {
+		return nil, false, nil
+	}
+	file, err := tree.TreeEntryFile(entry)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "path `%s`", p)
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return nil, false, errors.WithStack(err)
+	}
+	return []byte(content), true, nil
+}
+
+// decode parses a `.json` file as JSON and anything else as YAML.
+func decode(p string, content []byte) (interface{}, error) {
+	if path.Ext(p) == ".json" {
+		return parseJSON(content)
+	}
+	var doc interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, errors.Errorf("malformed YAML: %s", err)
+	}
+	return ep.JSONCompatible(doc), nil
+}
@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"fmt"
+	ep "github.com/fiibbb/gitdb/extended_plumbing"
+	"go.uber.org/fx"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"strings"
+)
+
+// Write is a write about to be committed.
+type Write struct {
+	Repo string
+	Ref  string
+	// Old is the tree of the ref head, nil for a new branch.
+	Old *object.Tree
+	New *object.Tree
+	// Changes lists the files that differ between Old and New.
+	Changes []*ep.FileChange
+}
+
+// Validator checks writes before they are committed.
+type Validator interface {
+	// Validate returns everything wrong with `w`. The returned error is for
+	// failing to validate, not for invalid writes.
+	Validate(w *Write) ([]*Failure, error)
+}
+
+// Failure is a reason to reject a write. `Path` is empty if the failure is not
+// about a particular path.
+type Failure struct {
+	Path   string
+	Reason string
+}
+
+// Error rejects a write with the failures of every validator.
+type Error struct {
+	Failures []*Failure
+}
+
+func (e *Error) Error() string {
+	var msgs []string
+	for _, f := range e.Failures {
+		if f.Path == "" {
+			msgs = append(msgs, f.Reason)
+		} else {
+			msgs = append(msgs, fmt.Sprintf("path `%s`: %s", f.Path, f.Reason))
+		}
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+// Out provides a validator to the fx graph, e.g.
+//
+//	func NewOwnersValidator(...) validation.Out {
+//	    return validation.Out{Validator: &ownersValidator{...}}
+//	}
+type Out struct {
+	fx.Out
+	Validator Validator `group:"validators"`
+}
+
+// Validators are all the validators provided through Out.
+type Validators []Validator
+
+type In struct {
+	fx.In
+	Validators []Validator `group:"validators"`
+}
+
+func NewValidators(in In) Validators {
+	return in.Validators
+}
+
+// Validate runs every validator on `w`, returning an *Error if any of them
+// fails. Failures reported by several validators are only listed once.
+func (vs Validators) Validate(w *Write) error {
+	var failures []*Failure
+	seen := map[Failure]bool{}
+	for _, v := range vs {
+		f, err := v.Validate(w)
+		if err != nil {
+			return err
+		}
+		for _, failure := range f {
+			if !seen[*failure] {
+				seen[*failure] = true
+				failures = append(failures, failure)
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return &Error{Failures: failures}
+	}
+	return nil
+}